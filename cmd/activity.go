@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var activitySince string
+
+var activityCmd = &cobra.Command{
+	Use:   "activity [list-uuid-or-name]",
+	Short: "Show a list's activity timeline",
+	Long: `Show who added, changed or completed items on a shopping list,
+as recorded by Bring.
+
+If no list is provided, uses the default list.
+
+Examples:
+  bring activity
+  bring activity Office --since 7d
+  bring activity --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runActivity,
+}
+
+func init() {
+	activityCmd.Flags().StringVar(&activitySince, "since", "", "only show activity newer than this (e.g. 24h, 7d)")
+	rootCmd.AddCommand(activityCmd)
+}
+
+// activityEvent is an activity event with its member name resolved.
+type activityEvent struct {
+	UUID           string       `json:"uuid"`
+	Type           string       `json:"type"`
+	Action         string       `json:"action"`
+	Time           time.Time    `json:"time"`
+	PublicUserUUID string       `json:"publicUserUuid"`
+	UserName       string       `json:"userName,omitempty"`
+	Items          []outputItem `json:"items"`
+}
+
+func runActivity(cmd *cobra.Command, args []string) error {
+	var since time.Time
+	if activitySince != "" {
+		d, err := parseDuration(activitySince)
+		if err != nil {
+			return err
+		}
+		since = time.Now().Add(-d)
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	argList := ""
+	if len(args) > 0 {
+		argList = args[0]
+	}
+	listUUID, err := resolveListUUID(client, argList)
+	if err != nil {
+		return err
+	}
+
+	events, err := fetchActivity(client, listUUID, since)
+	if err != nil {
+		return err
+	}
+
+	if isStructured() {
+		return printOutput(activityOutput{envelope: newEnvelope(true), List: listUUID, Events: events})
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No activity found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tUSER\tACTION\tITEMS")
+	for _, event := range events {
+		user := event.UserName
+		if user == "" {
+			user = event.PublicUserUUID
+		}
+		var items []string
+		for _, item := range event.Items {
+			items = append(items, item.ItemID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			event.Time.Local().Format("2006-01-02 15:04"), user, event.Action, strings.Join(items, ", "))
+	}
+	w.Flush()
+
+	return nil
+}
+
+// fetchActivity returns a list's activity events newer than since, with
+// member names resolved where possible.
+func fetchActivity(client *bringapi.Client, listUUID string, since time.Time) ([]activityEvent, error) {
+	activity, err := client.GetListActivity(listUUID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching list activity: %w", err)
+	}
+
+	names := memberNames(client, listUUID)
+
+	events := []activityEvent{}
+	for _, event := range activity.Timeline {
+		if !since.IsZero() && event.Content.SessionDate.Before(since) {
+			continue
+		}
+		events = append(events, activityEvent{
+			UUID:           event.Content.UUID,
+			Type:           event.Type,
+			Action:         activityAction(event.Type),
+			Time:           event.Content.SessionDate,
+			PublicUserUUID: event.Content.PublicUserUUID,
+			UserName:       names[event.Content.PublicUserUUID],
+			Items:          newOutputItems(event.Content.Items),
+		})
+	}
+
+	return events, nil
+}
+
+// memberNames maps the public UUIDs of a list's members to their names.
+// Members can't always be fetched, so failures leave the map empty.
+func memberNames(client *bringapi.Client, listUUID string) map[string]string {
+	names := make(map[string]string)
+	users, err := client.GetListUsers(listUUID)
+	if err != nil {
+		return names
+	}
+	for _, user := range users.Users {
+		names[user.PublicUUID] = user.Name
+	}
+	return names
+}
+
+// activityAction returns a readable name for an activity event type.
+func activityAction(eventType string) string {
+	switch eventType {
+	case bringapi.ActivityItemsAdded:
+		return "added"
+	case bringapi.ActivityItemsChanged:
+		return "changed"
+	case bringapi.ActivityItemsRemoved:
+		return "completed"
+	default:
+		return strings.ToLower(eventType)
+	}
+}
@@ -4,13 +4,22 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/julianfbeck/bring-cli/internal/api"
+	"github.com/google/uuid"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"github.com/spf13/cobra"
 )
 
 var (
-	addSpec string
-	addList string
+	addSpec  string
+	addList  string
+	addMerge bool
+	addForce bool
+
+	addUrgent     bool
+	addConvenient bool
+	addDiscounted bool
+	addNotify     bool
+	addNotifyType string
 )
 
 var addCmd = &cobra.Command{
@@ -18,61 +27,147 @@ var addCmd = &cobra.Command{
 	Short: "Add item(s) to a shopping list",
 	Long: `Add one or more items to a shopping list.
 
+Items already on the purchase list are skipped. If a specification is
+given, you are asked whether to merge it into the existing one; --merge
+does so without asking. Use --force to skip checking the list.
+
+--urgent, --convenient and --discounted flag the added items (see
+bring flag). --notify tells the other list members about the change
+with a changed-list notification; --notify-type=urgent-message asks them
+for the added items urgently instead. Changed-list notifications are
+sent at most once every couple of minutes per list; see bring config
+notify to send them automatically, and --notify=false to skip them.
+
 If no list is specified, uses the default list.
 
 Examples:
   bring add Milk
   bring add Bread --spec "2 loaves, whole wheat"
   bring add Eggs Butter Cheese
-  bring add "Orange Juice" --list abc123`,
+  bring add "Orange Juice" --list abc123
+  bring add Milk --spec "2L" --merge
+  bring add Coffee --urgent
+  bring add Milk --notify
+  bring add Milk --urgent --notify-type urgent-message`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runAdd,
 }
 
 func init() {
 	addCmd.Flags().StringVarP(&addSpec, "spec", "s", "", "item specification (quantity, notes)")
-	addCmd.Flags().StringVarP(&addList, "list", "l", "", "target list UUID")
+	addCmd.Flags().StringVarP(&addList, "list", "l", "", "target list UUID or name")
+	addCmd.Flags().BoolVarP(&addMerge, "merge", "m", false, "merge the specification into items already on the list")
+	addCmd.Flags().BoolVarP(&addForce, "force", "f", false, "add without checking the list for duplicates")
+	addCmd.Flags().BoolVarP(&addUrgent, "urgent", "u", false, "flag the items as urgent")
+	addCmd.Flags().BoolVar(&addConvenient, "convenient", false, "flag the items to buy if convenient")
+	addCmd.Flags().BoolVar(&addDiscounted, "discounted", false, "flag the items to buy on offer only")
+	addCmd.Flags().BoolVarP(&addNotify, "notify", "n", false, "notify list members about the change")
+	addCmd.Flags().StringVar(&addNotifyType, "notify-type", "", "notification to send: changed-list, urgent-message (implies --notify)")
 	rootCmd.AddCommand(addCmd)
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
+	notify := changeNotification(cmd, addNotify, false)
+	if addNotifyType != "" {
+		if cmd.Flags().Changed("notify") && !addNotify {
+			return fmt.Errorf("--notify-type can't be used with --notify=false")
+		}
+		kind, err := findNotifyKind(addNotifyType)
+		if err != nil {
+			return err
+		}
+		if kind.apiType == bringapi.NotifyActivityReaction {
+			return fmt.Errorf("--notify-type=%s is not supported by add", kind.name)
+		}
+		notify = &kind
+	}
+
 	client, err := getAuthenticatedClient()
 	if err != nil {
 		return err
 	}
 
-	listUUID, err := getDefaultListUUID(addList)
+	listUUID, err := resolveListUUID(client, addList)
 	if err != nil {
 		return err
 	}
 
-	// Build changes for all items
-	var changes []api.ItemChange
-	for _, item := range args {
-		changes = append(changes, api.ItemChange{
-			ItemID:    item,
-			Spec:      addSpec,
-			Operation: api.OperationAdd,
+	var current *bringapi.ListItemsResponse
+	var changes []bringapi.ItemChange
+	var results []bringapi.ItemResult
+	if addForce {
+		for _, item := range args {
+			changes = append(changes, bringapi.ItemChange{
+				ItemID:    item,
+				Spec:      addSpec,
+				Operation: bringapi.OperationAdd,
+			})
+			results = append(results, bringapi.ItemResult{Name: item, ItemID: item, Status: bringapi.ItemAdded, Spec: addSpec})
+		}
+	} else {
+		current, err = client.GetListItems(listUUID)
+		if err != nil {
+			return fmt.Errorf("fetching list items: %w", err)
+		}
+		changes, results = preflightAdd(current, args, addSpec, func(item bringapi.ListItem, spec string) bool {
+			return addMerge || confirm("%s is already on the list (%s). Merge %q into it?", item.ItemID, item.Specification, spec)
 		})
 	}
 
-	if err := client.UpdateItems(listUUID, changes); err != nil {
-		return fmt.Errorf("adding items: %w", err)
+	conditions := bringapi.PurchaseConditions{Urgent: addUrgent, Convenient: addConvenient, Discounted: addDiscounted}
+	if conditions != (bringapi.PurchaseConditions{}) {
+		changes = withConditions(changes, conditions)
+	}
+
+	if len(changes) > 0 {
+		if err := updateItems(client, listUUID, "add", current, changes); err != nil {
+			return fmt.Errorf("adding items: %w", err)
+		}
 	}
 
-	if isJSON() {
-		return printJSON(map[string]interface{}{
-			"success": true,
-			"items":   args,
-			"list":    listUUID,
+	added := resultItems(results, bringapi.ItemAdded, bringapi.ItemMerged)
+	skipped := resultItems(results, bringapi.ItemSkipped)
+
+	notified := ""
+	if len(added) > 0 {
+		notified = notifyChange(client, listUUID, notify, added)
+	}
+
+	if isStructured() {
+		return printOutput(itemsChangeOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Items:    nonNil(added),
+			Results:  newItemResults(results),
+			Notified: notified,
 		})
 	}
 
-	if len(args) == 1 {
-		printSuccess("Added %s to list", args[0])
-	} else {
-		printSuccess("Added %d items to list: %s", len(args), strings.Join(args, ", "))
+	if len(added) == 1 {
+		printSuccess("Added %s to list", added[0])
+	} else if len(added) > 1 {
+		printSuccess("Added %d items to list: %s", len(added), strings.Join(added, ", "))
+	}
+	if len(skipped) > 0 {
+		printWarning("already on the list, skipped: %s (use --merge or --force)", strings.Join(skipped, ", "))
+	}
+	if notificationSent(notified) {
+		printSuccess("Notified list users: %s", notify.describe(added))
 	}
 
 	return nil
 }
+
+// withConditions appends changes setting the purchase conditions of every
+// item added by changes. New items get their UUID here, so both changes
+// refer to the same item.
+func withConditions(changes []bringapi.ItemChange, conditions bringapi.PurchaseConditions) []bringapi.ItemChange {
+	flagged := make([]bringapi.ItemChange, 0, 2*len(changes))
+	for _, change := range changes {
+		if change.UUID == "" {
+			change.UUID = uuid.New().String()
+		}
+		flagged = append(flagged, change, bringapi.ConditionsChange(change.ItemID, change.UUID, conditions))
+	}
+	return flagged
+}
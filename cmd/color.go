@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/julianfbeck/bring-cli/internal/config"
+	"golang.org/x/term"
+)
+
+// Style roles used in human output. Each role maps to a color in the
+// palette, which can be overridden in the colors section of config.yaml.
+const (
+	roleHeader  = "header"
+	roleSection = "section"
+	roleSpec    = "spec"
+	roleUrgent  = "urgent"
+	roleBadge   = "badge"
+	roleDefault = "default"
+	roleSuccess = "success"
+	roleWarning = "warning"
+	roleError   = "error"
+	roleCursor  = "cursor"
+)
+
+// defaultPalette is used for roles the config doesn't override.
+var defaultPalette = map[string]string{
+	roleHeader:  "bold",
+	roleSection: "bold cyan",
+	roleSpec:    "dim",
+	roleUrgent:  "bold red",
+	roleBadge:   "yellow",
+	roleDefault: "green",
+	roleSuccess: "green",
+	roleWarning: "yellow",
+	roleError:   "red",
+	roleCursor:  "reverse",
+}
+
+// sgrCodes maps color and attribute names to ANSI SGR codes.
+var sgrCodes = map[string]string{
+	"bold": "1", "dim": "2", "italic": "3", "underline": "4", "reverse": "7",
+	"black": "30", "red": "31", "green": "32", "yellow": "33",
+	"blue": "34", "magenta": "35", "cyan": "36", "white": "37", "gray": "90",
+	"bright-red": "91", "bright-green": "92", "bright-yellow": "93",
+	"bright-blue": "94", "bright-magenta": "95", "bright-cyan": "96", "bright-white": "97",
+}
+
+var (
+	paletteOnce     sync.Once
+	palette         map[string]string
+	paletteWarnings []string
+)
+
+// loadPalette returns the SGR sequence of every role, combining the
+// default palette with the config. Unknown color names are skipped with
+// a warning; "none" disables styling for a role.
+func loadPalette() map[string]string {
+	paletteOnce.Do(func() {
+		colors := make(map[string]string, len(defaultPalette))
+		for role, color := range defaultPalette {
+			colors[role] = color
+		}
+		for role, color := range config.GetColors() {
+			if _, ok := defaultPalette[role]; !ok {
+				paletteWarnings = append(paletteWarnings, fmt.Sprintf("unknown color role in config: %s", role))
+				continue
+			}
+			colors[role] = color
+		}
+
+		palette = make(map[string]string, len(colors))
+		for role, color := range colors {
+			var codes []string
+			for _, name := range strings.FieldsFunc(strings.ToLower(color), func(r rune) bool { return r == ' ' || r == '+' }) {
+				if code, ok := sgrCodes[name]; ok {
+					codes = append(codes, code)
+				} else if isSGR(name) {
+					codes = append(codes, name)
+				} else if name != "none" {
+					paletteWarnings = append(paletteWarnings, fmt.Sprintf("unknown color %q for %s in config", name, role))
+				}
+			}
+			if len(codes) > 0 {
+				palette[role] = "\x1b[" + strings.Join(codes, ";") + "m"
+			}
+		}
+	})
+
+	// Warnings are styled too, so they are printed once the palette is set
+	warnings := paletteWarnings
+	paletteWarnings = nil
+	for _, warning := range warnings {
+		printWarning("%s", warning)
+	}
+	return palette
+}
+
+// isSGR reports whether s is a raw SGR parameter such as "38;5;208".
+func isSGR(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && r != ';' {
+			return false
+		}
+	}
+	return true
+}
+
+// colorEnabled reports whether output to f should be colored: not with
+// --no-color, NO_COLOR, TERM=dumb or structured output, and only on a terminal.
+func colorEnabled(f *os.File) bool {
+	if noColor || isStructured() || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// colorize styles s with the color of role if output to f is colored.
+func colorize(f *os.File, role, s string) string {
+	if s == "" || !colorEnabled(f) {
+		return s
+	}
+	seq, ok := loadPalette()[role]
+	if !ok {
+		return s
+	}
+	return seq + s + "\x1b[0m"
+}
+
+// style styles s for stdout.
+func style(role, s string) string {
+	return colorize(os.Stdout, role, s)
+}
+
+// cell is a table cell with an optional style role.
+type cell struct {
+	text string
+	role string
+}
+
+// printTable writes an aligned table like a tabwriter with a padding of
+// two, but pads cells before styling them so colors don't break the
+// alignment. Every line starts with indent.
+func printTable(w io.Writer, indent string, header []string, rows [][]cell) {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, c := range row {
+			if n := utf8.RuneCountInString(c.text); i < len(widths) && n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	pad := func(i int, text string) string {
+		if i == len(widths)-1 {
+			return text
+		}
+		return text + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(text)+2)
+	}
+
+	var line strings.Builder
+	for i, h := range header {
+		line.WriteString(pad(i, h))
+	}
+	fmt.Fprintln(w, indent+style(roleHeader, strings.TrimRight(line.String(), " ")))
+
+	for _, row := range rows {
+		line.Reset()
+		for i, c := range row {
+			text := c.text
+			if i == len(row)-1 {
+				line.WriteString(style(c.role, text))
+				continue
+			}
+			padded := pad(i, text)
+			line.WriteString(style(c.role, text) + padded[len(text):])
+		}
+		fmt.Fprintln(w, strings.TrimRight(indent+line.String(), " "))
+	}
+}
@@ -4,29 +4,62 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/julianfbeck/bring-cli/internal/api"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"github.com/spf13/cobra"
 )
 
-var completeList string
+var (
+	completeList   string
+	completeAll    bool
+	completeExact  bool
+	completeForce  bool
+	completeNotify bool
+)
 
 var completeCmd = &cobra.Command{
-	Use:   "complete <item>...",
+	Use:   "complete [item]...",
 	Short: "Mark item(s) as completed",
 	Long: `Mark one or more items as completed (moves to recently bought).
 
+Items are matched against the purchase list ignoring case and accents,
+by their localized name (see BRING_LOCALE) and with small typos.
+Use --exact to only accept exact item names. Items that are not on the
+purchase list are reported, and the command exits with an error.
+Use --force to send the names as given without checking the list.
+
+Use --all to check off the whole purchase list at checkout.
+
+--notify tells the other list members about the change afterwards:
+changed-list, or shopping-done with --all (see bring config notify to
+do so automatically).
+
 If no list is specified, uses the default list.
 
 Examples:
   bring complete Milk
+  bring complete milch --exact
   bring complete Eggs Butter Cheese
-  bring complete "Orange Juice" --list abc123`,
-	Args: cobra.MinimumNArgs(1),
+  bring complete "Orange Juice" --list abc123
+  bring complete --all
+  bring complete --all --notify`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if completeAll && len(args) > 0 {
+			return fmt.Errorf("--all does not take item arguments")
+		}
+		if !completeAll && len(args) == 0 {
+			return fmt.Errorf("requires at least 1 item, or --all")
+		}
+		return nil
+	},
 	RunE: runComplete,
 }
 
 func init() {
-	completeCmd.Flags().StringVarP(&completeList, "list", "l", "", "target list UUID")
+	completeCmd.Flags().StringVarP(&completeList, "list", "l", "", "target list UUID or name")
+	completeCmd.Flags().BoolVarP(&completeAll, "all", "a", false, "complete every item on the purchase list")
+	completeCmd.Flags().BoolVar(&completeExact, "exact", false, "only complete items whose name matches exactly")
+	completeCmd.Flags().BoolVarP(&completeForce, "force", "f", false, "complete without checking the list")
+	completeCmd.Flags().BoolVarP(&completeNotify, "notify", "n", false, "notify list members about the change")
 	rootCmd.AddCommand(completeCmd)
 }
 
@@ -36,37 +69,84 @@ func runComplete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	listUUID, err := getDefaultListUUID(completeList)
+	listUUID, err := resolveListUUID(client, completeList)
 	if err != nil {
 		return err
 	}
 
-	// Build changes for all items
-	var changes []api.ItemChange
-	for _, item := range args {
-		changes = append(changes, api.ItemChange{
-			ItemID:    item,
-			Operation: api.OperationComplete,
-		})
+	var current *bringapi.ListItemsResponse
+	var changes []bringapi.ItemChange
+	var results []bringapi.ItemResult
+	if completeForce && !completeAll {
+		for _, item := range args {
+			changes = append(changes, bringapi.ItemChange{
+				ItemID:    item,
+				Operation: bringapi.OperationComplete,
+			})
+			results = append(results, bringapi.ItemResult{Name: item, ItemID: item, Status: bringapi.ItemCompleted})
+		}
+	} else {
+		current, err = client.GetListItems(listUUID)
+		if err != nil {
+			return fmt.Errorf("fetching list items: %w", err)
+		}
+
+		matcher := bringapi.MatchExact
+		if completeAll {
+			args = nil
+			for _, item := range current.Items.Purchase {
+				args = append(args, item.ItemID)
+			}
+		} else if !completeExact {
+			matcher = (&itemResolver{client: client}).resolve
+		}
+
+		changes, results = preflightComplete(current, args, matcher)
 	}
 
-	if err := client.UpdateItems(listUUID, changes); err != nil {
-		return fmt.Errorf("completing items: %w", err)
+	if len(changes) > 0 {
+		if err := updateItems(client, listUUID, "complete", current, changes); err != nil {
+			return fmt.Errorf("completing items: %w", err)
+		}
 	}
 
-	if isJSON() {
-		return printJSON(map[string]interface{}{
-			"success": true,
-			"items":   args,
-			"list":    listUUID,
-		})
+	completed := resultItems(results, bringapi.ItemCompleted)
+	skipped := resultItems(results, bringapi.ItemSkipped)
+	failed := resultErrors(results)
+
+	notify := changeNotification(cmd, completeNotify, completeAll)
+	notified := ""
+	if len(completed) > 0 {
+		notified = notifyChange(client, listUUID, notify, nil)
 	}
 
-	if len(args) == 1 {
-		printSuccess("Completed %s", args[0])
-	} else {
-		printSuccess("Completed %d items: %s", len(args), strings.Join(args, ", "))
+	if isStructured() {
+		if err := printOutput(itemsChangeOutput{
+			envelope: newEnvelope(failed == nil),
+			List:     listUUID,
+			Items:    nonNil(completed),
+			Results:  newItemResults(results),
+			Notified: notified,
+		}); err != nil {
+			return err
+		}
+		return reported(failed)
+	}
+
+	switch {
+	case len(completed) == 1:
+		printSuccess("Completed %s", completed[0])
+	case len(completed) > 1:
+		printSuccess("Completed %d items: %s", len(completed), strings.Join(completed, ", "))
+	case completeAll:
+		printSuccess("Nothing to complete")
+	}
+	if len(skipped) > 0 {
+		printWarning("already completed: %s", strings.Join(skipped, ", "))
+	}
+	if notificationSent(notified) {
+		printSuccess("Notified list users: %s", notify.describe(nil))
 	}
 
-	return nil
+	return failed
 }
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+// Completions are served from a local cache, so pressing tab doesn't wait
+// for the API every time. Lists and items change often, so their entries
+// expire quickly; the item catalog hardly ever changes.
+const (
+	listsCacheTTL    = 5 * time.Minute
+	itemsCacheTTL    = 30 * time.Second
+	articlesCacheTTL = 24 * time.Hour
+
+	listsCacheFile = "lists.json"
+)
+
+func init() {
+	for _, c := range []*cobra.Command{listCmd, activityCmd, statsCmd, suggestCmd, setListCmd, listsRenameCmd, listsThemeCmd, listsDeleteCmd} {
+		c.ValidArgsFunction = completeListArg
+	}
+
+	addCmd.ValidArgsFunction = completeCatalogItems
+	recurAddCmd.ValidArgsFunction = completeCatalogItems
+	completeCmd.ValidArgsFunction = completeItems(bringapi.LocationPurchase)
+	flagCmd.ValidArgsFunction = completeItems(bringapi.LocationPurchase)
+	removeCmd.ValidArgsFunction = completeItems(bringapi.LocationPurchase, bringapi.LocationRecently)
+	restoreCmd.ValidArgsFunction = completeItems(bringapi.LocationRecently)
+
+	photoItems := completeItems(bringapi.LocationPurchase, bringapi.LocationRecently)
+	photoGetCmd.ValidArgsFunction = photoItems
+	photoSetCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return nil, cobra.ShellCompDirectiveDefault // the photo file
+		}
+		return photoItems(cmd, args, toComplete)
+	}
+}
+
+// registerFlagCompletions completes the --list flag of every command with
+// list names. It runs once all commands and their flags are set up.
+func registerFlagCompletions(c *cobra.Command) {
+	if c.LocalFlags().Lookup("list") != nil {
+		_ = c.RegisterFlagCompletionFunc("list", completeListFlag)
+	}
+	for _, child := range c.Commands() {
+		registerFlagCompletions(child)
+	}
+}
+
+// completeListArg completes a single list argument with list names.
+func completeListArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeListFlag(cmd, args, toComplete)
+}
+
+// completeListFlag completes list names, described by their UUID, and
+// list UUIDs once one is being typed.
+func completeListFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	lists, err := cachedLists()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, list := range lists {
+		if hasFoldPrefix(list.Name, toComplete) {
+			completions = append(completions, list.Name+"\t"+list.ListUUID)
+		} else if toComplete != "" && strings.HasPrefix(list.ListUUID, toComplete) {
+			completions = append(completions, list.ListUUID+"\t"+list.Name)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeItems returns a completion function offering the items at the
+// given locations of the list selected by --list, leaving out items
+// that are already arguments.
+func completeItems(locations ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		listUUID, err := completionListUUID(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		items, err := cached(itemsCacheFile(listUUID), itemsCacheTTL, func() (*bringapi.ListItemsResponse, error) {
+			client, err := getAuthenticatedClient()
+			if err != nil {
+				return nil, err
+			}
+			return client.GetListItems(listUUID)
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []string
+		for _, location := range locations {
+			onList := items.Items.Purchase
+			if location == bringapi.LocationRecently {
+				onList = items.Items.Recently
+			}
+			for _, item := range onList {
+				if hasFoldPrefix(item.ItemID, toComplete) && !containsFold(args, item.ItemID) {
+					completions = append(completions, item.ItemID+"\t"+item.Specification)
+				}
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeCatalogItems completes items from Bring's catalog in order,
+// described by their localized name (see BRING_LOCALE).
+func completeCatalogItems(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	locale := articleLocale()
+	translations, err := cached("articles-"+locale+".json", articlesCacheTTL, func() (map[string]string, error) {
+		client := newClient()
+		return client.GetArticleTranslations(locale)
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Items are matched by their ItemID or localized name, but always
+	// completed to the ItemID, which add expects
+	var completions []string
+	for itemID, name := range translations {
+		if (hasFoldPrefix(itemID, toComplete) || hasFoldPrefix(name, toComplete)) && !containsFold(args, itemID) {
+			completions = append(completions, itemID+"\t"+name)
+		}
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionListUUID returns the list selected by the command's --list
+// flag, or the default list, resolving names from the cache.
+func completionListUUID(cmd *cobra.Command) (string, error) {
+	flagValue := ""
+	if flag := cmd.Flag("list"); flag != nil {
+		flagValue = flag.Value.String()
+	}
+	listArg, err := getDefaultListUUID(flagValue)
+	if err != nil {
+		return "", err
+	}
+	if _, err := uuid.Parse(listArg); err == nil {
+		return listArg, nil
+	}
+
+	lists, err := cachedLists()
+	if err != nil {
+		return "", err
+	}
+	if list := findList(lists, listArg); list != nil {
+		return list.ListUUID, nil
+	}
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return "", err
+	}
+	return resolveListUUID(client, listArg)
+}
+
+// cachedLists returns the user's lists from the completion cache. The
+// client is only set up on a cache miss, since logging in with
+// credentials from the environment takes a request.
+func cachedLists() ([]bringapi.ShoppingList, error) {
+	return cached(listsCacheFile, listsCacheTTL, func() ([]bringapi.ShoppingList, error) {
+		client, err := getAuthenticatedClient()
+		if err != nil {
+			return nil, err
+		}
+		lists, err := client.GetLists()
+		if err != nil {
+			return nil, err
+		}
+		return lists.Lists, nil
+	})
+}
+
+// cached returns the value stored in the completion cache file name if
+// it is younger than ttl, or fetches and stores it. Cache errors are
+// ignored, since the cache only speeds up completion.
+func cached[T any](name string, ttl time.Duration, fetch func() (T, error)) (T, error) {
+	var value T
+	path, err := completionCachePath(name)
+	if err == nil {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < ttl {
+			if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &value) == nil {
+				return value, nil
+			}
+		}
+	}
+
+	value, err = fetch()
+	if err != nil {
+		return value, err
+	}
+
+	if path != "" {
+		if data, err := json.Marshal(value); err == nil && os.MkdirAll(filepath.Dir(path), 0700) == nil {
+			_ = os.WriteFile(path, data, 0600)
+		}
+	}
+	return value, nil
+}
+
+// invalidateCompletionCache removes cached completion data, e.g. after a
+// list was changed.
+func invalidateCompletionCache(names ...string) {
+	for _, name := range names {
+		if path, err := completionCachePath(name); err == nil {
+			_ = os.Remove(path)
+		}
+	}
+}
+
+// clearCompletionCache removes all cached completion data, e.g. when the
+// account changes.
+func clearCompletionCache() {
+	if path, err := completionCachePath(""); err == nil {
+		_ = os.RemoveAll(path)
+	}
+}
+
+// itemsCacheFile returns the completion cache file of a list's items.
+func itemsCacheFile(listUUID string) string {
+	return "items-" + listUUID + ".json"
+}
+
+// completionCachePath returns the path of a completion cache file.
+func completionCachePath(name string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bring-cli", "completion", name), nil
+}
+
+// hasFoldPrefix reports whether s starts with prefix, ignoring case.
+func hasFoldPrefix(s, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+func TestCompletionsFromCacheDontLogIn(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	// Logging in with these fails, so completions must come from the cache
+	t.Setenv("BRING_EMAIL", "nobody@example.com")
+	t.Setenv("BRING_PASSWORD", "wrong")
+	t.Setenv("BRING_LIST", "Zuhause")
+
+	const listUUID = "2f3c1a9e-7b4d-4e8a-9c61-0d5e8f7a2b13"
+	write := func(name string, v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(cache, "bring-cli", "completion", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(listsCacheFile, []bringapi.ShoppingList{{ListUUID: listUUID, Name: "Zuhause"}})
+	write(itemsCacheFile(listUUID), testList())
+
+	lists, _ := completeListFlag(&cobra.Command{}, nil, "zu")
+	if want := []string{"Zuhause\t" + listUUID}; !reflect.DeepEqual(lists, want) {
+		t.Errorf("list completions = %q, want %q", lists, want)
+	}
+
+	items, _ := completeItems(bringapi.LocationPurchase)(&cobra.Command{}, []string{"Brot"}, "")
+	if want := []string{"Milch\t2L"}; !reflect.DeepEqual(items, want) {
+		t.Errorf("item completions = %q, want %q", items, want)
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/julianfbeck/bring-cli/internal/config"
 	"github.com/spf13/cobra"
@@ -29,9 +28,42 @@ Example:
 	RunE: runSetList,
 }
 
+var (
+	notifyChanges      bool
+	notifyShoppingDone bool
+	notifyDebounce     string
+)
+
+var configNotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Configure automatic notifications",
+	Long: `Configure the notifications sent automatically after changing a list.
+
+With --changes, add, remove and complete send a changed-list
+notification. With --shopping-done, complete --all sends shopping-done.
+Automatic notifications are debounced: after one was sent for a list,
+more of the same kind are skipped for the debounce period, so a script
+making many changes notifies once.
+
+Without flags, shows the current settings.
+
+Examples:
+  bring config notify
+  bring config notify --changes --shopping-done
+  bring config notify --debounce 5m
+  bring config notify --changes=false`,
+	Args: cobra.NoArgs,
+	RunE: runConfigNotify,
+}
+
 func init() {
+	configNotifyCmd.Flags().BoolVar(&notifyChanges, "changes", false, "notify after add, remove and complete")
+	configNotifyCmd.Flags().BoolVar(&notifyShoppingDone, "shopping-done", false, "notify shopping-done after complete --all")
+	configNotifyCmd.Flags().StringVar(&notifyDebounce, "debounce", "", "minimum time between automatic notifications per list (e.g. 30s, 2m)")
+
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(setListCmd)
+	configCmd.AddCommand(configNotifyCmd)
 }
 
 func runSetList(cmd *cobra.Command, args []string) error {
@@ -48,33 +80,75 @@ func runSetList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("fetching lists: %w", err)
 	}
 
-	var listUUID string
-	var listName string
+	list := findList(lists.Lists, listArg)
+	if list == nil {
+		return fmt.Errorf("list not found: %s\nRun 'bring lists' to see available lists", listArg)
+	}
 
-	// Check if argument is a UUID or a name
-	for _, list := range lists.Lists {
-		if list.ListUUID == listArg {
-			listUUID = list.ListUUID
-			listName = list.Name
-			break
-		}
-		if strings.EqualFold(list.Name, listArg) {
-			listUUID = list.ListUUID
-			listName = list.Name
-			break
-		}
+	if err := config.SetDefaultList(list.ListUUID); err != nil {
+		return fmt.Errorf("saving default list: %w", err)
 	}
 
-	if listUUID == "" {
-		return fmt.Errorf("list not found: %s\nRun 'bring lists' to see available lists", listArg)
+	if isStructured() {
+		return printOutput(listChangeOutput{envelope: newEnvelope(true), List: newOutputList(*list)})
 	}
 
-	if err := config.SetDefaultList(listUUID); err != nil {
-		return fmt.Errorf("saving default list: %w", err)
+	if !isQuiet() {
+		fmt.Printf("Default list set to: %s (%s)\n", list.Name, list.ListUUID)
+	}
+
+	return nil
+}
+
+func runConfigNotify(cmd *cobra.Command, args []string) error {
+	settings := config.GetNotifySettings()
+
+	// Only settings flags are saved, not e.g. --json
+	flags := cmd.Flags()
+	changed := false
+	if flags.Changed("changes") {
+		settings.Changes = notifyChanges
+		changed = true
+	}
+	if flags.Changed("shopping-done") {
+		settings.ShoppingDone = notifyShoppingDone
+		changed = true
+	}
+	if flags.Changed("debounce") {
+		settings.Debounce = notifyDebounce
+		changed = true
+	}
+
+	debounce, err := settings.DebounceDuration()
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		if err := config.SetNotifySettings(settings); err != nil {
+			return fmt.Errorf("saving notify settings: %w", err)
+		}
 	}
 
+	if isStructured() {
+		return printOutput(notifySettingsOutput{
+			envelope:     newEnvelope(true),
+			Changes:      settings.Changes,
+			ShoppingDone: settings.ShoppingDone,
+			Debounce:     debounce.String(),
+		})
+	}
+
+	onOff := func(on bool) string {
+		if on {
+			return "on"
+		}
+		return "off"
+	}
 	if !isQuiet() {
-		fmt.Printf("Default list set to: %s (%s)\n", listName, listUUID)
+		fmt.Printf("Changed-list after changes: %s\n", onOff(settings.Changes))
+		fmt.Printf("Shopping-done after complete --all: %s\n", onOff(settings.ShoppingDone))
+		fmt.Printf("Debounce: %s\n", debounce)
 	}
 
 	return nil
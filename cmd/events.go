@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+// Events of the NDJSON stream, written with --ndjson.
+const (
+	eventStart   = "start"   // the command started
+	eventItem    = "item"    // an item was changed on a list
+	eventWarning = "warning" // a warning, printed to stderr otherwise
+	eventResult  = "result"  // the command's output
+	eventError   = "error"   // the command failed
+)
+
+// streamEvent is a line of the NDJSON stream.
+type streamEvent struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Event         string        `json:"event"`
+	Time          time.Time     `json:"time"`
+	Command       string        `json:"command"`
+	Args          []string      `json:"args,omitempty"`
+	List          string        `json:"list,omitempty"`
+	Change        *outputChange `json:"change,omitempty"`
+	Message       string        `json:"message,omitempty"`
+	Data          interface{}   `json:"data,omitempty"`
+}
+
+var (
+	streamMu      sync.Mutex
+	streamCommand string
+)
+
+// isNDJSON returns true if the NDJSON event stream is requested.
+func isNDJSON() bool {
+	return outputMode == outputNDJSON
+}
+
+// startStream emits the start event of a command.
+func startStream(cmd *cobra.Command, args []string) {
+	streamCommand = cmd.CommandPath()
+	emitEvent(streamEvent{Event: eventStart, Args: args})
+}
+
+// emitEvent writes an event to stdout as a single line, so consumers can
+// process events as they happen.
+func emitEvent(e streamEvent) {
+	e.SchemaVersion = schemaVersion
+	e.Time = time.Now().UTC()
+	e.Command = streamCommand
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		data, _ = json.Marshal(streamEvent{
+			SchemaVersion: schemaVersion,
+			Event:         eventError,
+			Time:          e.Time,
+			Command:       e.Command,
+			Message:       fmt.Sprintf("encoding %s event: %v", e.Event, err),
+		})
+	}
+
+	streamMu.Lock()
+	defer streamMu.Unlock()
+	fmt.Fprintln(os.Stdout, string(data))
+}
+
+// emitItemEvents emits an item event for every change made to a list.
+func emitItemEvents(listUUID string, changes []bringapi.ItemChange) {
+	for _, change := range newOutputChanges(changes) {
+		change := change
+		emitEvent(streamEvent{Event: eventItem, List: listUUID, Change: &change})
+	}
+}
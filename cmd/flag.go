@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagList       string
+	flagUrgent     bool
+	flagConvenient bool
+	flagDiscounted bool
+	flagClear      bool
+	flagExact      bool
+)
+
+var flagCmd = &cobra.Command{
+	Use:   "flag <item>...",
+	Short: "Mark items as urgent, convenient or discounted",
+	Long: `Set the urgent, convenient and discounted flags of items on the
+purchase list.
+
+Only the flags given are changed; pass e.g. --urgent=false to unset one,
+or --clear to unset all of them. Without any flag, the current flags are
+shown.
+
+Items are matched like in complete. Use --exact to only accept exact
+item names.
+
+If no list is specified, uses the default list.
+
+Examples:
+  bring flag Milk --urgent
+  bring flag Coffee Butter --discounted
+  bring flag Milk --urgent=false
+  bring flag Milk --clear`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFlag,
+}
+
+func init() {
+	flagCmd.Flags().StringVarP(&flagList, "list", "l", "", "target list UUID or name")
+	flagCmd.Flags().BoolVarP(&flagUrgent, "urgent", "u", false, "item is needed urgently")
+	flagCmd.Flags().BoolVar(&flagConvenient, "convenient", false, "buy the item if convenient")
+	flagCmd.Flags().BoolVar(&flagDiscounted, "discounted", false, "only buy the item on offer")
+	flagCmd.Flags().BoolVar(&flagClear, "clear", false, "unset all flags")
+	flagCmd.Flags().BoolVar(&flagExact, "exact", false, "only flag items whose name matches exactly")
+	rootCmd.AddCommand(flagCmd)
+}
+
+func runFlag(cmd *cobra.Command, args []string) error {
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, flagList)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.GetListItems(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching list items: %w", err)
+	}
+
+	matcher := bringapi.MatchExact
+	if !flagExact {
+		matcher = (&itemResolver{client: client}).resolve
+	}
+
+	flags := cmd.Flags()
+	update := flagClear || flags.Changed("urgent") || flags.Changed("convenient") || flags.Changed("discounted")
+
+	var changes []bringapi.ItemChange
+	var results []itemResult
+	var flagged []string
+	var errs []error
+	planned := make(map[string]bool)
+	for _, arg := range args {
+		result := itemResult{Name: arg}
+
+		item, err := matcher(arg, current.Items.Purchase)
+		if err != nil {
+			result.Status = bringapi.ItemFailed
+			if errors.Is(err, bringapi.ErrItemNotFound) {
+				result.Status = bringapi.ItemNotFound
+			}
+			result.Error = err.Error()
+			results = append(results, result)
+			errs = append(errs, err)
+			continue
+		}
+
+		result.ItemID = item.ItemID
+		result.UUID = item.UUID
+		result.Spec = item.Specification
+		result.Status = bringapi.ItemSkipped
+
+		conditions := item.Conditions()
+		if update {
+			if flagClear {
+				conditions = bringapi.PurchaseConditions{}
+			}
+			if flags.Changed("urgent") {
+				conditions.Urgent = flagUrgent
+			}
+			if flags.Changed("convenient") {
+				conditions.Convenient = flagConvenient
+			}
+			if flags.Changed("discounted") {
+				conditions.Discounted = flagDiscounted
+			}
+
+			if key := bringapi.ItemKey(*item); conditions != item.Conditions() && !planned[key] {
+				planned[key] = true
+				changes = append(changes, bringapi.ConditionsChange(item.ItemID, item.UUID, conditions))
+				result.Status = bringapi.ItemFlagged
+				flagged = append(flagged, item.ItemID)
+			}
+		}
+		result.Flags = conditions.Badges()
+
+		results = append(results, result)
+	}
+
+	if len(changes) > 0 {
+		if err := updateItems(client, listUUID, "flag", current, changes); err != nil {
+			return fmt.Errorf("flagging items: %w", err)
+		}
+	}
+
+	if isStructured() {
+		if err := printOutput(itemsChangeOutput{
+			envelope: newEnvelope(len(errs) == 0),
+			List:     listUUID,
+			Items:    nonNil(flagged),
+			Results:  results,
+		}); err != nil {
+			return err
+		}
+		return reported(errors.Join(errs...))
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		badges := "no flags"
+		if len(result.Flags) > 0 {
+			badges = strings.Join(result.Flags, ", ")
+		}
+		if result.Status == bringapi.ItemFlagged {
+			printSuccess("Flagged %s: %s", result.ItemID, badges)
+		} else {
+			fmt.Printf("%s: %s\n", result.ItemID, badges)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julianfbeck/bring-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyList  string
+	historySince string
+	historyItem  string
+	historyLimit int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show changes made with bring",
+	Long: `Show the local journal of every change this CLI has made to your lists.
+
+Each row is one item change, with the account that made it and whether
+the server accepted it. The journal is stored in
+~/.config/bring-cli/journal.jsonl.
+
+Examples:
+  bring history
+  bring history --since 7d --item Milch
+  bring history --list Office --json`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVarP(&historyList, "list", "l", "", "only show changes to this list (UUID or name)")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "only show changes newer than this (e.g. 24h, 7d)")
+	historyCmd.Flags().StringVarP(&historyItem, "item", "i", "", "only show changes to this item")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 0, "show at most this many of the newest changes")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	filter := journal.Filter{ItemID: historyItem}
+
+	if historySince != "" {
+		since, err := parseDuration(historySince)
+		if err != nil {
+			return err
+		}
+		filter.Since = time.Now().Add(-since)
+	}
+
+	if historyList != "" {
+		filter.ListUUID = historyList
+		// Only names need the API, so history works offline for UUIDs
+		if _, err := uuid.Parse(historyList); err != nil {
+			client, err := getAuthenticatedClient()
+			if err != nil {
+				return err
+			}
+			if filter.ListUUID, err = resolveListUUID(client, historyList); err != nil {
+				return err
+			}
+		}
+	}
+
+	entries, err := journal.Load()
+	if err != nil {
+		return err
+	}
+
+	records := journal.Records(entries, filter)
+	if historyLimit > 0 && len(records) > historyLimit {
+		records = records[len(records)-historyLimit:]
+	}
+
+	if isStructured() {
+		return printOutput(historyOutput{envelope: newEnvelope(true), Records: newOutputRecords(records)})
+	}
+
+	if len(records) == 0 {
+		fmt.Println("No history found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tPROFILE\tLIST\tOPERATION\tITEM\tSPECIFICATION\tRESULT")
+	for _, r := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Time.Local().Format("2006-01-02 15:04"), r.Profile, r.ListUUID, r.Operation, r.ItemID, r.Spec, r.Result)
+	}
+	w.Flush()
+
+	return nil
+}
@@ -3,26 +3,43 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"strings"
 
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"github.com/spf13/cobra"
 )
 
+var (
+	listUrgent     bool
+	listConvenient bool
+	listDiscounted bool
+)
+
 var listCmd = &cobra.Command{
-	Use:   "list [list-uuid]",
+	Use:   "list [list-uuid-or-name]",
 	Short: "Show items in a shopping list",
 	Long: `Display all items in a shopping list.
 
-If no list UUID is provided, uses the default list.
+Items flagged as urgent, convenient or discounted, and items with a
+photo, are marked with badges. --urgent, --convenient and --discounted
+only show items with those flags.
+
+If no list is provided, uses the default list.
 
 Examples:
   bring list
   bring list abc123-def456
+  bring list Office
+  bring list --urgent
   bring list --json`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runList,
 }
 
 func init() {
+	listCmd.Flags().BoolVarP(&listUrgent, "urgent", "u", false, "only show urgent items")
+	listCmd.Flags().BoolVar(&listConvenient, "convenient", false, "only show items to buy if convenient")
+	listCmd.Flags().BoolVar(&listDiscounted, "discounted", false, "only show items to buy on offer")
 	rootCmd.AddCommand(listCmd)
 }
 
@@ -36,7 +53,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		argListUUID = args[0]
 	}
-	listUUID, err := getDefaultListUUID(argListUUID)
+	listUUID, err := resolveListUUID(client, argListUUID)
 	if err != nil {
 		return err
 	}
@@ -46,37 +63,111 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("fetching list items: %w", err)
 	}
 
-	if isJSON() {
-		return printJSON(items)
+	filter := bringapi.PurchaseConditions{Urgent: listUrgent, Convenient: listConvenient, Discounted: listDiscounted}
+	filtered := filter != (bringapi.PurchaseConditions{})
+	if filtered {
+		items.Items.Purchase = filterConditions(items.Items.Purchase, filter)
+		items.Items.Recently = filterConditions(items.Items.Recently, filter)
+	}
+
+	empty := len(items.Items.Purchase) == 0 && len(items.Items.Recently) == 0
+
+	// Photos are only marked, so the list is still shown without them
+	photos := make(map[string]bool)
+	if !empty {
+		details, err := client.GetItemDetails(listUUID)
+		if err != nil {
+			printWarning("could not fetch item photos: %v", err)
+		}
+		for _, detail := range details {
+			if detail.ImageURL != "" {
+				photos[strings.ToLower(detail.ItemID)] = true
+			}
+		}
+	}
+
+	if isStructured() {
+		return printOutput(listItemsOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Items: listItems{
+				Purchase: markPhotos(newOutputItems(items.Items.Purchase), photos),
+				Recently: markPhotos(newOutputItems(items.Items.Recently), photos),
+			},
+		})
 	}
 
-	if len(items.Items.Purchase) == 0 && len(items.Items.Recently) == 0 {
-		fmt.Println("List is empty")
+	if empty {
+		if filtered {
+			fmt.Printf("No %s items\n", strings.Join(filter.Badges(), ", "))
+		} else {
+			fmt.Println("List is empty")
+		}
 		return nil
 	}
 
 	if len(items.Items.Purchase) > 0 {
-		fmt.Println("To Buy:")
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "  ITEM\tSPECIFICATION")
-		for _, item := range items.Items.Purchase {
-			fmt.Fprintf(w, "  %s\t%s\n", item.ItemID, item.Specification)
-		}
-		w.Flush()
+		fmt.Println(style(roleSection, "To Buy:"))
+		printItems(items.Items.Purchase, photos)
 	}
 
 	if len(items.Items.Recently) > 0 {
 		if len(items.Items.Purchase) > 0 {
 			fmt.Println()
 		}
-		fmt.Println("Recently Completed:")
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "  ITEM\tSPECIFICATION")
-		for _, item := range items.Items.Recently {
-			fmt.Fprintf(w, "  %s\t%s\n", item.ItemID, item.Specification)
-		}
-		w.Flush()
+		fmt.Println(style(roleSection, "Recently Completed:"))
+		printItems(items.Items.Recently, photos)
 	}
 
 	return nil
 }
+
+// printItems prints items as an indented table, highlighting urgent items.
+func printItems(items []bringapi.ListItem, photos map[string]bool) {
+	rows := make([][]cell, 0, len(items))
+	for _, item := range items {
+		itemRole := ""
+		if item.Conditions().Urgent {
+			itemRole = roleUrgent
+		}
+		rows = append(rows, []cell{
+			{item.ItemID, itemRole},
+			{item.Specification, roleSpec},
+			{itemBadges(item, photos), roleBadge},
+		})
+	}
+	printTable(os.Stdout, "  ", []string{"ITEM", "SPECIFICATION", "FLAGS"}, rows)
+}
+
+// markPhotos sets HasPhoto on the items whose lowercased ItemID is in
+// photos.
+func markPhotos(items []outputItem, photos map[string]bool) []outputItem {
+	for i := range items {
+		items[i].HasPhoto = photos[strings.ToLower(items[i].ItemID)]
+	}
+	return items
+}
+
+// filterConditions returns the items that have every condition set in want.
+func filterConditions(items []bringapi.ListItem, want bringapi.PurchaseConditions) []bringapi.ListItem {
+	kept := []bringapi.ListItem{}
+	for _, item := range items {
+		if item.Conditions().Includes(want) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// itemBadges formats the flags set on an item, e.g. "[urgent] [discounted]",
+// and marks items whose lowercased ItemID is in photos.
+func itemBadges(item bringapi.ListItem, photos map[string]bool) string {
+	var badges []string
+	for _, badge := range item.Conditions().Badges() {
+		badges = append(badges, "["+badge+"]")
+	}
+	if photos[strings.ToLower(item.ItemID)] {
+		badges = append(badges, "[photo]")
+	}
+	return strings.Join(badges, " ")
+}
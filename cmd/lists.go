@@ -3,26 +3,100 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"strings"
 
-	"github.com/julianfbeck/bring-cli/internal/api"
+	"github.com/google/uuid"
 	"github.com/julianfbeck/bring-cli/internal/config"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"github.com/spf13/cobra"
 )
 
+var (
+	listsCreateTheme string
+	listsRenameTheme string
+	listsYes         bool
+)
+
 var listsCmd = &cobra.Command{
 	Use:   "lists",
 	Short: "List all shopping lists",
 	Long: `Display all shopping lists associated with your Bring account.
 
+Use the subcommands to create, rename and delete lists.
+
 Example:
   bring lists
   bring lists --json`,
+	Args: cobra.NoArgs,
 	RunE: runLists,
 }
 
+var listsCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a shopping list",
+	Long: `Create a new shopping list.
+
+Themes: home (default), office, bbq, school, christmas.
+
+Examples:
+  bring lists create Party
+  bring lists create Office --theme office`,
+	Args: cobra.ExactArgs(1),
+	RunE: runListsCreate,
+}
+
+var listsRenameCmd = &cobra.Command{
+	Use:   "rename <list> <new-name>",
+	Short: "Rename a shopping list",
+	Long: `Rename a shopping list, given by UUID or name.
+
+Use --theme to change the list's theme as well.
+
+Examples:
+  bring lists rename Einkaufen Groceries
+  bring lists rename Office Work --theme office`,
+	Args: cobra.ExactArgs(2),
+	RunE: runListsRename,
+}
+
+var listsThemeCmd = &cobra.Command{
+	Use:   "theme <list> <theme>",
+	Short: "Change the theme of a shopping list",
+	Long: `Change the theme of a shopping list, given by UUID or name.
+
+Themes: home, office, bbq, school, christmas.
+
+Example:
+  bring lists theme Party bbq`,
+	Args: cobra.ExactArgs(2),
+	RunE: runListsTheme,
+}
+
+var listsDeleteCmd = &cobra.Command{
+	Use:   "delete <list>",
+	Short: "Delete a shopping list",
+	Long: `Delete a shopping list, given by UUID or name.
+
+You are asked for confirmation; use --yes to skip it, e.g. in scripts.
+Lists shared with others are only removed for you.
+
+Examples:
+  bring lists delete Party
+  bring lists delete Party --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runListsDelete,
+}
+
 func init() {
+	listsCreateCmd.Flags().StringVarP(&listsCreateTheme, "theme", "t", "home", "list theme")
+	listsRenameCmd.Flags().StringVarP(&listsRenameTheme, "theme", "t", "", "also change the list theme")
+	listsDeleteCmd.Flags().BoolVarP(&listsYes, "yes", "y", false, "delete without asking for confirmation")
+
 	rootCmd.AddCommand(listsCmd)
+	listsCmd.AddCommand(listsCreateCmd)
+	listsCmd.AddCommand(listsRenameCmd)
+	listsCmd.AddCommand(listsThemeCmd)
+	listsCmd.AddCommand(listsDeleteCmd)
 }
 
 func runLists(cmd *cobra.Command, args []string) error {
@@ -36,8 +110,12 @@ func runLists(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("fetching lists: %w", err)
 	}
 
-	if isJSON() {
-		return printJSON(lists)
+	if isStructured() {
+		output := listsOutput{envelope: newEnvelope(true), Lists: []outputList{}}
+		for _, list := range lists.Lists {
+			output.Lists = append(output.Lists, newOutputList(list))
+		}
+		return printOutput(output)
 	}
 
 	if len(lists.Lists) == 0 {
@@ -51,31 +129,191 @@ func runLists(cmd *cobra.Command, args []string) error {
 		defaultList = creds.DefaultList
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NAME\tUUID\tDEFAULT")
+	rows := make([][]cell, 0, len(lists.Lists))
 	for _, list := range lists.Lists {
-		isDefault := ""
+		nameRole, isDefault := "", ""
 		if list.ListUUID == defaultList {
-			isDefault = "*"
+			nameRole, isDefault = roleDefault, "*"
+		}
+		rows = append(rows, []cell{
+			{list.Name, nameRole},
+			{list.ListUUID, roleSpec},
+			{bringapi.ThemeName(list.Theme), ""},
+			{isDefault, roleDefault},
+		})
+	}
+	printTable(os.Stdout, "", []string{"NAME", "UUID", "THEME", "DEFAULT"}, rows)
+
+	return nil
+}
+
+func runListsCreate(cmd *cobra.Command, args []string) error {
+	theme, err := bringapi.ListTheme(listsCreateTheme)
+	if err != nil {
+		return err
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	lists, err := client.GetLists()
+	if err != nil {
+		return fmt.Errorf("fetching lists: %w", err)
+	}
+	if existing := findList(lists.Lists, args[0]); existing != nil {
+		return fmt.Errorf("list already exists: %s (%s)", existing.Name, existing.ListUUID)
+	}
+
+	list, err := client.CreateList(args[0], theme)
+	if err != nil {
+		return fmt.Errorf("creating list: %w", err)
+	}
+	invalidateCompletionCache(listsCacheFile)
+
+	if isStructured() {
+		return printOutput(listChangeOutput{envelope: newEnvelope(true), List: newOutputList(*list)})
+	}
+
+	printSuccess("Created list %s (%s)", list.Name, list.ListUUID)
+	return nil
+}
+
+func runListsRename(cmd *cobra.Command, args []string) error {
+	theme := ""
+	if listsRenameTheme != "" {
+		var err error
+		if theme, err = bringapi.ListTheme(listsRenameTheme); err != nil {
+			return err
+		}
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := lookupList(client, args[0])
+	if err != nil {
+		return err
+	}
+
+	name := args[1]
+	renamed := list.Name != name
+	if renamed {
+		if err := client.RenameList(list.ListUUID, name); err != nil {
+			return fmt.Errorf("renaming list: %w", err)
+		}
+		invalidateCompletionCache(listsCacheFile)
+	}
+	rethemed := theme != "" && theme != list.Theme
+	if rethemed {
+		if err := client.SetListTheme(list.ListUUID, theme); err != nil {
+			return fmt.Errorf("changing list theme: %w", err)
+		}
+		list.Theme = theme
+	}
+
+	if isStructured() {
+		return printOutput(listChangeOutput{
+			envelope: newEnvelope(true),
+			List:     outputList{ListUUID: list.ListUUID, Name: name, Theme: list.Theme},
+			Previous: list.Name,
+		})
+	}
+
+	if renamed {
+		printSuccess("Renamed list %s to %s", list.Name, name)
+	}
+	if rethemed {
+		printSuccess("Changed theme of %s to %s", name, bringapi.ThemeName(theme))
+	}
+	if !renamed && !rethemed {
+		printSuccess("Nothing to change")
+	}
+	return nil
+}
+
+func runListsTheme(cmd *cobra.Command, args []string) error {
+	theme, err := bringapi.ListTheme(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := lookupList(client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := client.SetListTheme(list.ListUUID, theme); err != nil {
+		return fmt.Errorf("changing list theme: %w", err)
+	}
+	list.Theme = theme
+
+	if isStructured() {
+		return printOutput(listChangeOutput{envelope: newEnvelope(true), List: newOutputList(*list)})
+	}
+
+	printSuccess("Changed theme of %s to %s", list.Name, bringapi.ThemeName(theme))
+	return nil
+}
+
+func runListsDelete(cmd *cobra.Command, args []string) error {
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := lookupList(client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !listsYes && !confirm("Delete list %s (%s) and all its items?", list.Name, list.ListUUID) {
+		return fmt.Errorf("not deleting list %s: confirm the prompt or use --yes", list.Name)
+	}
+
+	if err := client.DeleteList(list.ListUUID); err != nil {
+		return fmt.Errorf("deleting list: %w", err)
+	}
+	invalidateCompletionCache(listsCacheFile, itemsCacheFile(list.ListUUID))
+
+	// Don't leave a deleted list as the default
+	creds, _ := config.GetCredentials()
+	if creds != nil && creds.DefaultList == list.ListUUID {
+		creds.DefaultList = ""
+		if err := config.SaveCredentials(creds); err != nil {
+			printWarning("could not clear the default list: %v", err)
+		} else {
+			printWarning("%s was the default list; run 'bring config set-list' to choose another", list.Name)
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\n", list.Name, list.ListUUID, isDefault)
 	}
-	w.Flush()
 
+	if isStructured() {
+		return printOutput(listChangeOutput{envelope: newEnvelope(true), List: newOutputList(*list)})
+	}
+
+	printSuccess("Deleted list %s", list.Name)
 	return nil
 }
 
 // getAuthenticatedClient returns an authenticated API client.
 // It first checks for BRING_EMAIL and BRING_PASSWORD environment variables.
 // If not set, it falls back to stored credentials from config file.
-func getAuthenticatedClient() (*api.Client, error) {
+func getAuthenticatedClient() (*bringapi.Client, error) {
 	// Check for environment variables first
 	email := os.Getenv("BRING_EMAIL")
 	password := os.Getenv("BRING_PASSWORD")
 
 	if email != "" && password != "" {
 		// Login with environment variables
-		client := api.NewClient(nil, nil)
+		client := newClient()
 		_, err := client.Login(email, password)
 		if err != nil {
 			return nil, fmt.Errorf("login with environment variables failed: %w", err)
@@ -92,10 +330,16 @@ func getAuthenticatedClient() (*api.Client, error) {
 		return nil, fmt.Errorf("not logged in. Set BRING_EMAIL and BRING_PASSWORD environment variables, or run 'bring login'")
 	}
 
-	client := api.NewClient(creds, config.SaveCredentials)
+	client := newClient(bringapi.WithCredentials(creds), bringapi.WithTokenStore(config.TokenStore{}))
 	return client, nil
 }
 
+// newClient returns an API client identifying as this version of the CLI.
+func newClient(opts ...bringapi.Option) *bringapi.Client {
+	opts = append([]bringapi.Option{bringapi.WithUserAgent("bring-cli/" + version)}, opts...)
+	return bringapi.NewClient(opts...)
+}
+
 // getDefaultListUUID returns the list UUID to use.
 // Priority: flag > BRING_LIST env var > stored default list
 func getDefaultListUUID(flagValue string) (string, error) {
@@ -117,3 +361,48 @@ func getDefaultListUUID(flagValue string) (string, error) {
 
 	return "", fmt.Errorf("no list specified. Use --list flag, set BRING_LIST environment variable, or run 'bring login'")
 }
+
+// resolveListUUID returns the list UUID to use, like getDefaultListUUID,
+// but also accepts a list name and resolves it via the API.
+func resolveListUUID(client *bringapi.Client, flagValue string) (string, error) {
+	listArg, err := getDefaultListUUID(flagValue)
+	if err != nil {
+		return "", err
+	}
+
+	// UUIDs need no lookup
+	if _, err := uuid.Parse(listArg); err == nil {
+		return listArg, nil
+	}
+
+	list, err := lookupList(client, listArg)
+	if err != nil {
+		return "", err
+	}
+
+	return list.ListUUID, nil
+}
+
+// lookupList returns the list matching a UUID or name.
+func lookupList(client *bringapi.Client, listArg string) (*bringapi.ShoppingList, error) {
+	lists, err := client.GetLists()
+	if err != nil {
+		return nil, fmt.Errorf("fetching lists: %w", err)
+	}
+
+	list := findList(lists.Lists, listArg)
+	if list == nil {
+		return nil, fmt.Errorf("list not found: %s\nRun 'bring lists' to see available lists", listArg)
+	}
+	return list, nil
+}
+
+// findList returns the list matching a UUID or a case-insensitive name.
+func findList(lists []bringapi.ShoppingList, listArg string) *bringapi.ShoppingList {
+	for i, list := range lists {
+		if list.ListUUID == listArg || strings.EqualFold(list.Name, listArg) {
+			return &lists[i]
+		}
+	}
+	return nil
+}
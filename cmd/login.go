@@ -6,8 +6,8 @@ import (
 	"os"
 	"strings"
 
-	"github.com/julianfbeck/bring-cli/internal/api"
 	"github.com/julianfbeck/bring-cli/internal/config"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -68,23 +68,19 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("password is required")
 	}
 
-	// Authenticate
-	client := api.NewClient(nil, nil)
+	// Authenticate, saving the credentials to the config
+	client := newClient(bringapi.WithTokenStore(config.TokenStore{}))
 	authResp, err := client.Login(email, password)
 	if err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
+	clearCompletionCache()
 
-	// Save credentials
-	if err := config.SaveCredentials(client.GetCredentials()); err != nil {
-		return fmt.Errorf("saving credentials: %w", err)
-	}
-
-	if isJSON() {
-		return printJSON(map[string]interface{}{
-			"success":      true,
-			"email":        authResp.Email,
-			"default_list": authResp.BringListUUID,
+	if isStructured() {
+		return printOutput(loginOutput{
+			envelope:    newEnvelope(true),
+			Email:       authResp.Email,
+			DefaultList: authResp.BringListUUID,
 		})
 	}
 
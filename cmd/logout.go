@@ -23,9 +23,10 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	if err := config.ClearCredentials(); err != nil {
 		return err
 	}
+	clearCompletionCache()
 
-	if isJSON() {
-		return printJSON(map[string]bool{"success": true})
+	if isStructured() {
+		return printOutput(newEnvelope(true))
 	}
 
 	printSuccess("Logged out successfully")
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/julianfbeck/bring-cli/internal/match"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+// defaultLocale is the locale of item names matched besides the
+// German ItemIDs, unless BRING_LOCALE is set.
+const defaultLocale = "en-US"
+
+// articleLocale returns the locale used for localized item names.
+func articleLocale() string {
+	if locale := os.Getenv("BRING_LOCALE"); locale != "" {
+		return locale
+	}
+	return defaultLocale
+}
+
+// itemResolver resolves item arguments to items on a list. Localized
+// names are only downloaded once an argument isn't an exact ItemID.
+type itemResolver struct {
+	client       *bringapi.Client
+	translations map[string]string
+	loaded       bool
+}
+
+// resolve returns the item in items matching query.
+func (r *itemResolver) resolve(query string, items []bringapi.ListItem) (*bringapi.ListItem, error) {
+	for i, item := range items {
+		if item.ItemID == query {
+			return &items[i], nil
+		}
+	}
+
+	if !r.loaded {
+		r.loaded = true
+		// Matching still works without localized names, so failures are ignored
+		r.translations, _ = r.client.GetArticleTranslations(articleLocale())
+	}
+
+	return match.Resolve(query, items, r.translations)
+}
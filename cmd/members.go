@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var (
+	membersList string
+	membersYes  bool
+)
+
+var membersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "Show and manage the members of a shopping list",
+	Long: `Show who a shopping list is shared with.
+
+Use the subcommands to invite and remove members.
+If no list is specified, uses the default list.
+
+Examples:
+  bring members
+  bring members --list Office --json`,
+	Args: cobra.NoArgs,
+	RunE: runMembers,
+}
+
+var membersInviteCmd = &cobra.Command{
+	Use:   "invite <email>",
+	Short: "Invite someone to a shopping list",
+	Long: `Invite the Bring user with the given email address to a shopping list.
+
+The email address must belong to a Bring account.
+
+Examples:
+  bring members invite anna@example.com
+  bring members invite anna@example.com --list Office`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMembersInvite,
+}
+
+var membersRemoveCmd = &cobra.Command{
+	Use:   "remove <public-uuid|email>",
+	Short: "Remove someone from a shopping list",
+	Long: `Remove a member from a shopping list, given by public UUID or email.
+
+You are asked for confirmation; use --yes to skip it, e.g. in scripts.
+To leave a list yourself, use bring lists delete.
+
+Examples:
+  bring members remove anna@example.com
+  bring members remove 4a5b6c7d-... --list Office --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMembersRemove,
+}
+
+func init() {
+	membersCmd.PersistentFlags().StringVarP(&membersList, "list", "l", "", "target list UUID or name")
+	membersRemoveCmd.Flags().BoolVarP(&membersYes, "yes", "y", false, "remove without asking for confirmation")
+
+	rootCmd.AddCommand(membersCmd)
+	membersCmd.AddCommand(membersInviteCmd)
+	membersCmd.AddCommand(membersRemoveCmd)
+}
+
+func runMembers(cmd *cobra.Command, args []string) error {
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, membersList)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.GetListUsers(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching members: %w", err)
+	}
+
+	if isStructured() {
+		output := membersOutput{envelope: newEnvelope(true), List: listUUID, Members: []outputMember{}}
+		for _, user := range users.Users {
+			output.Members = append(output.Members, newOutputMember(user))
+		}
+		return printOutput(output)
+	}
+
+	if len(users.Users) == 0 {
+		fmt.Println("No members found")
+		return nil
+	}
+
+	self := ""
+	if creds := client.GetCredentials(); creds != nil {
+		self = creds.PublicUUID
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tEMAIL\tPUBLIC UUID\tYOU")
+	for _, user := range users.Users {
+		you := ""
+		if user.PublicUUID == self {
+			you = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", user.Name, user.Email, user.PublicUUID, you)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func runMembersInvite(cmd *cobra.Command, args []string) error {
+	address, err := mail.ParseAddress(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid email address: %s", args[0])
+	}
+	email := address.Address
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, membersList)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.GetListUsers(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching members: %w", err)
+	}
+	if member := findMember(users.Users, email); member != nil {
+		return fmt.Errorf("%s is already a member of this list", email)
+	}
+
+	user, err := client.InviteToList(listUUID, email)
+	if errors.Is(err, bringapi.ErrUserNotFound) {
+		return fmt.Errorf("%s is not a Bring user: ask them to sign up in the Bring app first", email)
+	}
+	if err != nil {
+		return fmt.Errorf("inviting member: %w", err)
+	}
+
+	if isStructured() {
+		return printOutput(memberChangeOutput{envelope: newEnvelope(true), List: listUUID, Member: outputMember{PublicUUID: user.PublicUUID, Name: user.Name, Email: user.Email}})
+	}
+
+	printSuccess("Invited %s to list", email)
+	return nil
+}
+
+func runMembersRemove(cmd *cobra.Command, args []string) error {
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, membersList)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.GetListUsers(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching members: %w", err)
+	}
+
+	member := findMember(users.Users, args[0])
+	if member == nil {
+		return fmt.Errorf("not a member of this list: %s\nRun 'bring members' to see the members", args[0])
+	}
+	if creds := client.GetCredentials(); creds != nil && member.PublicUUID == creds.PublicUUID {
+		return fmt.Errorf("you can't remove yourself; use 'bring lists delete' to leave the list")
+	}
+
+	name := member.Name
+	if name == "" {
+		name = member.Email
+	}
+	if !membersYes && !confirm("Remove %s from the list?", name) {
+		return fmt.Errorf("not removing %s: confirm the prompt or use --yes", name)
+	}
+
+	if err := client.RemoveListMember(listUUID, member.PublicUUID); err != nil {
+		return fmt.Errorf("removing member: %w", err)
+	}
+
+	if isStructured() {
+		return printOutput(memberChangeOutput{envelope: newEnvelope(true), List: listUUID, Member: newOutputMember(*member)})
+	}
+
+	printSuccess("Removed %s from list", name)
+	return nil
+}
+
+// findMember returns the member matching a public UUID or email address.
+func findMember(users []bringapi.ListUser, arg string) *bringapi.ListUser {
+	for i, user := range users {
+		if user.PublicUUID == arg || (user.Email != "" && strings.EqualFold(user.Email, arg)) {
+			return &users[i]
+		}
+	}
+	return nil
+}
@@ -2,16 +2,47 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/julianfbeck/bring-cli/internal/api"
+	"github.com/julianfbeck/bring-cli/internal/config"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"github.com/spf13/cobra"
 )
 
 var (
-	notifyType string
-	notifyList string
+	notifyType     string
+	notifyList     string
+	notifyItems    []string
+	notifyActivity string
+	notifyReaction string
 )
 
+// notifyKind describes a notification type that can be sent from the CLI.
+type notifyKind struct {
+	name     string
+	apiType  string
+	message  string
+	needItem bool
+}
+
+// notifyKinds are the notification types, by friendly name.
+var notifyKinds = []notifyKind{
+	{"going-shopping", bringapi.NotifyGoingShopping, "Going shopping!", false},
+	{"changed-list", bringapi.NotifyChangedList, "List updated", false},
+	{"shopping-done", bringapi.NotifyShoppingDone, "Shopping done!", false},
+	{"urgent-message", bringapi.NotifyUrgentMessage, "Urgently needed: %s", true},
+	{"reaction", bringapi.NotifyActivityReaction, "", false},
+}
+
+// reactions maps friendly reaction names to API reaction types.
+var reactions = map[string]string{
+	"thumbs-up": bringapi.ReactionThumbsUp,
+	"monocle":   bringapi.ReactionMonocle,
+	"drooling":  bringapi.ReactionDrooling,
+	"heart":     bringapi.ReactionHeart,
+}
+
 var notifyCmd = &cobra.Command{
 	Use:   "notify",
 	Short: "Send notification to list users",
@@ -21,68 +52,245 @@ Notification types:
   going-shopping  - Let others know you're heading to the store
   changed-list    - Notify that the list was updated
   shopping-done   - Let others know you finished shopping
+  urgent-message  - Ask others to buy items urgently (needs --item)
+  reaction        - React to an activity event (see bring activity)
+                    with --reaction thumbs-up, monocle, drooling or heart
+
+Reactions go to the member who caused the event given by --activity,
+which defaults to the newest event by someone else.
 
 If no list is specified, uses the default list.
 
 Examples:
   bring notify
   bring notify --type going-shopping
-  bring notify --type shopping-done --list abc123`,
+  bring notify --type shopping-done --list abc123
+  bring notify --type urgent-message --item Milch
+  bring notify --type reaction --reaction heart`,
+	Args: cobra.NoArgs,
 	RunE: runNotify,
 }
 
 func init() {
-	notifyCmd.Flags().StringVarP(&notifyType, "type", "t", "going-shopping", "notification type: going-shopping, changed-list, shopping-done")
-	notifyCmd.Flags().StringVarP(&notifyList, "list", "l", "", "target list UUID")
+	notifyCmd.Flags().StringVarP(&notifyType, "type", "t", "going-shopping", "notification type: "+notifyKindNames())
+	notifyCmd.Flags().StringVarP(&notifyList, "list", "l", "", "target list UUID or name")
+	notifyCmd.Flags().StringSliceVarP(&notifyItems, "item", "i", nil, "item for urgent-message (repeatable)")
+	notifyCmd.Flags().StringVar(&notifyActivity, "activity", "", "activity event UUID to react to (default: newest by someone else)")
+	notifyCmd.Flags().StringVar(&notifyReaction, "reaction", "thumbs-up", "reaction: thumbs-up, monocle, drooling, heart")
 	rootCmd.AddCommand(notifyCmd)
 }
 
 func runNotify(cmd *cobra.Command, args []string) error {
+	kind, err := findNotifyKind(notifyType)
+	if err != nil {
+		return err
+	}
+
 	client, err := getAuthenticatedClient()
 	if err != nil {
 		return err
 	}
 
-	listUUID, err := getDefaultListUUID(notifyList)
+	listUUID, err := resolveListUUID(client, notifyList)
 	if err != nil {
 		return err
 	}
 
-	// Map friendly names to API constants
-	var apiNotifyType string
-	switch notifyType {
-	case "going-shopping":
-		apiNotifyType = api.NotifyGoingShopping
-	case "changed-list":
-		apiNotifyType = api.NotifyChangedList
-	case "shopping-done":
-		apiNotifyType = api.NotifyShoppingDone
-	default:
-		return fmt.Errorf("invalid notification type: %s (use: going-shopping, changed-list, shopping-done)", notifyType)
+	if kind.apiType == bringapi.NotifyActivityReaction {
+		return runReaction(client, listUUID)
 	}
 
-	if err := client.Notify(listUUID, apiNotifyType, nil); err != nil {
-		return fmt.Errorf("sending notification: %w", err)
+	if err := sendNotification(client, listUUID, kind, notifyItems); err != nil {
+		return err
 	}
 
-	if isJSON() {
-		return printJSON(map[string]interface{}{
-			"success": true,
-			"type":    notifyType,
-			"list":    listUUID,
+	if isStructured() {
+		return printOutput(notifyOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Type:     kind.name,
+			Items:    notifyItems,
 		})
 	}
 
-	var message string
-	switch notifyType {
-	case "going-shopping":
-		message = "Notified list users: Going shopping!"
-	case "changed-list":
-		message = "Notified list users: List updated"
-	case "shopping-done":
-		message = "Notified list users: Shopping done!"
+	printSuccess("Notified list users: %s", kind.describe(notifyItems))
+	return nil
+}
+
+// runReaction sends a reaction to an activity event.
+func runReaction(client *bringapi.Client, listUUID string) error {
+	reaction, ok := reactions[notifyReaction]
+	if !ok {
+		return fmt.Errorf("invalid reaction: %s (use: thumbs-up, monocle, drooling, heart)", notifyReaction)
+	}
+
+	events, err := fetchActivity(client, listUUID, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	self := ""
+	if creds := client.GetCredentials(); creds != nil {
+		self = creds.PublicUUID
+	}
+
+	var event *activityEvent
+	for i := range events {
+		e := &events[i]
+		if notifyActivity != "" {
+			if e.UUID == notifyActivity {
+				event = e
+				break
+			}
+			continue
+		}
+		if e.PublicUserUUID != self && (event == nil || e.Time.After(event.Time)) {
+			event = e
+		}
+	}
+	if event == nil {
+		if notifyActivity != "" {
+			return fmt.Errorf("activity event not found: %s\nRun 'bring activity --json' to see event UUIDs", notifyActivity)
+		}
+		return fmt.Errorf("no activity by other members to react to")
 	}
-	printSuccess(message)
 
+	err = client.React(listUUID, event.PublicUserUUID, bringapi.ActivityReaction{
+		ModuleUUID:   event.UUID,
+		ModuleType:   event.Type,
+		ReactionType: reaction,
+	})
+	if err != nil {
+		return fmt.Errorf("sending reaction: %w", err)
+	}
+
+	if isStructured() {
+		return printOutput(notifyOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Type:     "reaction",
+			Reaction: notifyReaction,
+			Activity: event.UUID,
+		})
+	}
+
+	user := event.UserName
+	if user == "" {
+		user = event.PublicUserUUID
+	}
+	printSuccess("Reacted with %s to %s %s", notifyReaction, user, event.Action)
 	return nil
 }
+
+// sendNotification validates and sends a notification of the given kind.
+func sendNotification(client *bringapi.Client, listUUID string, kind notifyKind, items []string) error {
+	if kind.needItem && len(items) == 0 {
+		return fmt.Errorf("%s needs at least one item (use --item)", kind.name)
+	}
+	if !kind.needItem && len(items) > 0 {
+		return fmt.Errorf("%s doesn't take items", kind.name)
+	}
+
+	if err := client.Notify(listUUID, kind.apiType, items); err != nil {
+		return fmt.Errorf("sending notification: %w", err)
+	}
+	return nil
+}
+
+// changeNotification returns the notification to send after a list was
+// changed, or nil for none. An explicit --notify flag wins over the
+// configured settings; shoppingDone is set when the whole list was
+// completed.
+func changeNotification(cmd *cobra.Command, enabled, shoppingDone bool) *notifyKind {
+	settings := config.GetNotifySettings()
+
+	name := ""
+	switch {
+	case cmd.Flags().Changed("notify") && !enabled:
+		return nil
+	case shoppingDone && (enabled || settings.ShoppingDone):
+		name = "shopping-done"
+	case enabled || settings.Changes:
+		name = "changed-list"
+	default:
+		return nil
+	}
+
+	kind, _ := findNotifyKind(name)
+	return &kind
+}
+
+// notifyDebounced is returned by notifyChange for a notification skipped
+// because the same one was sent to the list recently.
+const notifyDebounced = "debounced"
+
+// notifyChange sends a notification after a list was changed and returns
+// its name, notifyDebounced, or "" if none was sent. Notifications without
+// items are debounced across invocations, so a script making many changes
+// only notifies once; only sent notifications count. Failures only warn,
+// since the change itself succeeded.
+func notifyChange(client *bringapi.Client, listUUID string, kind *notifyKind, items []string) string {
+	if kind == nil {
+		return ""
+	}
+
+	if !kind.needItem {
+		window, err := config.GetNotifySettings().DebounceDuration()
+		if err != nil {
+			printWarning("%v", err)
+			window = config.DefaultNotifyDebounce
+		}
+		debounced, err := config.NotificationDebounced(listUUID, kind.apiType, window)
+		if err != nil {
+			printWarning("could not check recent notifications: %v", err)
+		} else if debounced {
+			printWarning("not notifying list users: %s was already sent within %s", kind.name, window)
+			return notifyDebounced
+		}
+		items = nil
+	}
+
+	if err := sendNotification(client, listUUID, *kind, items); err != nil {
+		printWarning("%v", err)
+		return ""
+	}
+	if !kind.needItem {
+		if err := config.RecordNotification(listUUID, kind.apiType); err != nil {
+			printWarning("could not record the notification: %v", err)
+		}
+	}
+	return kind.name
+}
+
+// notificationSent reports whether notified, as returned by notifyChange,
+// is a sent notification.
+func notificationSent(notified string) bool {
+	return notified != "" && notified != notifyDebounced
+}
+
+// findNotifyKind returns the notification type with the given friendly name.
+func findNotifyKind(name string) (notifyKind, error) {
+	for _, kind := range notifyKinds {
+		if kind.name == name {
+			return kind, nil
+		}
+	}
+	return notifyKind{}, fmt.Errorf("invalid notification type: %s (use: %s)", name, notifyKindNames())
+}
+
+// notifyKindNames lists the friendly names of all notification types.
+func notifyKindNames() string {
+	var names []string
+	for _, kind := range notifyKinds {
+		names = append(names, kind.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// describe returns the confirmation message for a sent notification.
+func (k notifyKind) describe(items []string) string {
+	if k.needItem {
+		return fmt.Sprintf(k.message, strings.Join(items, ", "))
+	}
+	return k.message
+}
@@ -0,0 +1,417 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/itchyny/gojq"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats selected with --output. Table is the human output each
+// command prints itself; the others are produced by printOutput.
+const (
+	outputTable  = "table"
+	outputJSON   = "json"
+	outputYAML   = "yaml"
+	outputCSV    = "csv"
+	outputTSV    = "tsv"
+	outputPlain  = "plain"
+	outputFormat = "format" // set by --format
+	outputNDJSON = "ndjson" // set by --ndjson
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML, outputCSV, outputTSV, outputPlain}
+
+var (
+	outputMode     = outputTable
+	outputTemplate *template.Template
+	outputQuery    *gojq.Code
+)
+
+// recordKeys are the JSON fields of a command's output holding its
+// records, in order of preference. Outputs without any are a single
+// record.
+var recordKeys = []string{"results", "lists", "events", "suggestions", "members", "records", "rules", "templates", "items", "added", "undone"}
+
+// listRow is an item of a list along with where it is on the list.
+type listRow struct {
+	outputItem
+	Location string `json:"location"`
+}
+
+// setupOutput validates the --output, --format, --json, --query and
+// --ndjson flags.
+func setupOutput() error {
+	mode := outputFlag
+	if ndjsonFlag {
+		if mode != "" || formatFlag != "" || queryFlag != "" || jsonOut {
+			return fmt.Errorf("--ndjson can't be combined with --json, --output, --format or --query")
+		}
+		outputMode = outputNDJSON
+		return nil
+	}
+	if queryFlag != "" {
+		if formatFlag != "" || (mode != "" && mode != outputJSON) {
+			return fmt.Errorf("--query only works with JSON output")
+		}
+		// Errors are reported as JSON from here on
+		outputMode = outputJSON
+		q, err := gojq.Parse(queryFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --query: %w", err)
+		}
+		code, err := gojq.Compile(q)
+		if err != nil {
+			return fmt.Errorf("invalid --query: %w", err)
+		}
+		outputQuery = code
+		mode = outputJSON
+	}
+
+	switch {
+	case formatFlag != "":
+		if mode != "" {
+			return fmt.Errorf("--format can't be combined with --output")
+		}
+		funcs := template.FuncMap{
+			"join":  strings.Join,
+			"upper": strings.ToUpper,
+			"lower": strings.ToLower,
+			"json": func(v interface{}) (string, error) {
+				data, err := json.Marshal(v)
+				return string(data), err
+			},
+		}
+		tmpl, err := template.New("format").Funcs(funcs).Parse(formatFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --format template: %w", err)
+		}
+		outputTemplate = tmpl
+		mode = outputFormat
+	case jsonOut:
+		if mode != "" && mode != outputJSON {
+			return fmt.Errorf("--json can't be combined with --output %s", mode)
+		}
+		mode = outputJSON
+	case mode == "":
+		mode = outputTable
+	}
+
+	if mode != outputFormat && !containsFold(outputFormats, mode) {
+		return fmt.Errorf("invalid output format: %s (use: %s)", mode, strings.Join(outputFormats, ", "))
+	}
+	outputMode = strings.ToLower(mode)
+	return nil
+}
+
+// isStructured returns true if machine-readable output is requested,
+// i.e. anything but the human table output.
+func isStructured() bool {
+	return outputMode != outputTable
+}
+
+// printOutput prints a command's result in the selected output format.
+// Formats with one line per record print the records of the result (see
+// recordKeys).
+func printOutput(v interface{}) error {
+	switch outputMode {
+	case outputYAML:
+		// Go through JSON so field names match the JSON output
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		return encoder.Encode(generic)
+	case outputCSV, outputTSV, outputPlain:
+		return printRecords(os.Stdout, outputRecords(v))
+	case outputNDJSON:
+		emitEvent(streamEvent{Event: eventResult, Data: v})
+		return nil
+	case outputFormat:
+		for _, record := range outputRecords(v) {
+			var b strings.Builder
+			if err := outputTemplate.Execute(&b, record); err != nil {
+				return fmt.Errorf("applying --format: %w", err)
+			}
+			fmt.Fprintln(os.Stdout, strings.TrimSuffix(b.String(), "\n"))
+		}
+		return nil
+	default:
+		if outputQuery != nil {
+			return printQuery(v)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+}
+
+// printQuery prints the results of --query on v, one per line. Strings
+// are printed as is, like jq -r; other values as JSON.
+func printQuery(v interface{}) error {
+	// gojq works on the values encoding/json decodes to
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	iter := outputQuery.Run(input)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := result.(error); ok {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				break
+			}
+			return fmt.Errorf("applying --query: %w", err)
+		}
+		if s, ok := result.(string); ok {
+			fmt.Println(s)
+			continue
+		}
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outputRecords returns the records of a command's output.
+func outputRecords(v interface{}) []interface{} {
+	if output, ok := v.(listItemsOutput); ok {
+		var rows []interface{}
+		for _, item := range output.Items.Purchase {
+			rows = append(rows, listRow{item, bringapi.LocationPurchase})
+		}
+		for _, item := range output.Items.Recently {
+			rows = append(rows, listRow{item, bringapi.LocationRecently})
+		}
+		return rows
+	}
+
+	rv := indirect(reflect.ValueOf(v))
+	switch rv.Kind() {
+	case reflect.Slice:
+		return sliceRecords(rv)
+	case reflect.Struct:
+		for _, key := range recordKeys {
+			if field := jsonField(rv, key); field.Kind() == reflect.Slice && !field.IsNil() {
+				return sliceRecords(field)
+			}
+		}
+	}
+	return []interface{}{v}
+}
+
+// jsonField returns the field of a struct with the given JSON name.
+func jsonField(rv reflect.Value, name string) reflect.Value {
+	var header []string
+	var fields [][]int
+	structColumns(rv.Type(), nil, &header, &fields)
+	for i, h := range header {
+		if h == name {
+			return rv.FieldByIndex(fields[i])
+		}
+	}
+	return reflect.Value{}
+}
+
+// sliceRecords returns the elements of a slice.
+func sliceRecords(rv reflect.Value) []interface{} {
+	records := make([]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		records = append(records, rv.Index(i).Interface())
+	}
+	return records
+}
+
+// printRecords prints records as CSV or TSV with a header, or as plain
+// tab-separated values without one.
+func printRecords(w io.Writer, records []interface{}) error {
+	header, rows := recordColumns(records)
+
+	if outputMode == outputPlain {
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return nil
+	}
+
+	cw := csv.NewWriter(w)
+	if outputMode == outputTSV {
+		cw.Comma = '\t'
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// recordColumns returns the column names and values of records. Struct
+// fields are named after their JSON names, in declaration order.
+func recordColumns(records []interface{}) ([]string, [][]string) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	first := indirect(reflect.ValueOf(records[0]))
+	switch first.Kind() {
+	case reflect.Struct:
+		var header []string
+		var fields [][]int
+		structColumns(first.Type(), nil, &header, &fields)
+
+		rows := make([][]string, 0, len(records))
+		for _, record := range records {
+			rv := indirect(reflect.ValueOf(record))
+			row := make([]string, len(fields))
+			for i, index := range fields {
+				if field, err := rv.FieldByIndexErr(index); err == nil {
+					row[i] = formatValue(field)
+				}
+			}
+			rows = append(rows, row)
+		}
+		return header, rows
+
+	case reflect.Map:
+		keys := make(map[string]bool)
+		for _, record := range records {
+			for _, key := range indirect(reflect.ValueOf(record)).MapKeys() {
+				keys[fmt.Sprint(key.Interface())] = true
+			}
+		}
+		header := make([]string, 0, len(keys))
+		for key := range keys {
+			header = append(header, key)
+		}
+		sort.Strings(header)
+
+		rows := make([][]string, 0, len(records))
+		for _, record := range records {
+			rv := indirect(reflect.ValueOf(record))
+			row := make([]string, len(header))
+			for i, key := range header {
+				if value := rv.MapIndex(reflect.ValueOf(key)); value.IsValid() {
+					row[i] = formatValue(value)
+				}
+			}
+			rows = append(rows, row)
+		}
+		return header, rows
+
+	default:
+		rows := make([][]string, 0, len(records))
+		for _, record := range records {
+			rows = append(rows, []string{formatValue(reflect.ValueOf(record))})
+		}
+		return []string{"value"}, rows
+	}
+}
+
+// structColumns collects the JSON names and field indexes of a struct's
+// exported fields, flattening embedded structs.
+func structColumns(t reflect.Type, parent []int, header *[]string, fields *[][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			structColumns(field.Type, index, header, fields)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		*header = append(*header, name)
+		*fields = append(*fields, index)
+	}
+}
+
+// formatValue formats a value for a CSV cell: scalars as text, lists of
+// scalars comma-separated and anything else as JSON.
+func formatValue(rv reflect.Value) string {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return ""
+	}
+
+	if t, ok := rv.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch rv.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Int32, reflect.Float64, reflect.Float32:
+		return fmt.Sprint(rv.Interface())
+	case reflect.Slice:
+		var values []string
+		for i := 0; i < rv.Len(); i++ {
+			elem := indirect(rv.Index(i))
+			if elem.Kind() == reflect.Struct || elem.Kind() == reflect.Map || elem.Kind() == reflect.Slice {
+				values = nil
+				break
+			}
+			values = append(values, fmt.Sprint(elem.Interface()))
+		}
+		if values != nil || rv.Len() == 0 {
+			return strings.Join(values, ",")
+		}
+	}
+
+	data, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return fmt.Sprint(rv.Interface())
+	}
+	return string(data)
+}
+
+// indirect dereferences pointers and interfaces.
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.IsValid() && (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
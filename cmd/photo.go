@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+// maxPhotoSize is the largest image accepted for upload.
+const maxPhotoSize = 10 << 20
+
+// photoExtensions maps image content types to the extension photos of that
+// type are saved with.
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var (
+	photoList  string
+	photoFile  string
+	photoExact bool
+	photoForce bool
+)
+
+var photoCmd = &cobra.Command{
+	Use:   "photo",
+	Short: "Attach and download item photos",
+	Long: `Attach a photo to an item, or download the photo attached to it.
+
+Items with a photo are marked in bring list.`,
+}
+
+var photoSetCmd = &cobra.Command{
+	Use:   "set <item> <file>",
+	Short: "Attach a photo to an item",
+	Long: `Upload a JPEG or PNG image as the photo of an item on the list,
+replacing any existing photo.
+
+Items are matched like in complete. If no list is specified, uses the
+default list.
+
+Examples:
+  bring photo set Coffee coffee.jpg
+  bring photo set Milk milk.png --list Office`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPhotoSet,
+}
+
+var photoGetCmd = &cobra.Command{
+	Use:   "get <item>",
+	Short: "Download the photo of an item",
+	Long: `Download the photo attached to an item on the list.
+
+The photo is saved as <item>.jpg (or .png, depending on the image) unless
+--file is given; use --file - to write it to stdout (not with --json,
+--output or --ndjson). Existing files are only overwritten with --force.
+
+Examples:
+  bring photo get Coffee
+  bring photo get Coffee -f coffee.jpg --force
+  bring photo get Coffee -f - > coffee.jpg
+  bring photo get Coffee -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPhotoGet,
+}
+
+func init() {
+	photoCmd.PersistentFlags().StringVarP(&photoList, "list", "l", "", "target list UUID or name")
+	photoCmd.PersistentFlags().BoolVar(&photoExact, "exact", false, "only accept exact item names")
+	photoGetCmd.Flags().StringVarP(&photoFile, "file", "f", "", "file to save the photo to (- for stdout)")
+	photoGetCmd.Flags().BoolVar(&photoForce, "force", false, "overwrite an existing file")
+
+	rootCmd.AddCommand(photoCmd)
+	photoCmd.AddCommand(photoSetCmd)
+	photoCmd.AddCommand(photoGetCmd)
+}
+
+// resolvePhotoItem resolves an item name against the purchase and recently
+// completed items of a list.
+func resolvePhotoItem(client *bringapi.Client, listUUID, name string) (*bringapi.ListItem, error) {
+	current, err := client.GetListItems(listUUID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching list items: %w", err)
+	}
+
+	matcher := bringapi.MatchExact
+	if !photoExact {
+		matcher = (&itemResolver{client: client}).resolve
+	}
+	onList := append(append([]bringapi.ListItem{}, current.Items.Purchase...), current.Items.Recently...)
+	return matcher(name, onList)
+}
+
+func runPhotoSet(cmd *cobra.Command, args []string) error {
+	info, err := os.Stat(args[1])
+	if err != nil {
+		return fmt.Errorf("reading photo: %w", err)
+	}
+	if info.Size() > maxPhotoSize {
+		return fmt.Errorf("photo is too large: %d bytes (at most %d)", info.Size(), maxPhotoSize)
+	}
+
+	image, err := os.ReadFile(args[1])
+	if err != nil {
+		return fmt.Errorf("reading photo: %w", err)
+	}
+	if contentType := http.DetectContentType(image); contentType != "image/jpeg" && contentType != "image/png" {
+		return fmt.Errorf("unsupported photo format %s: use a JPEG or PNG image", contentType)
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, photoList)
+	if err != nil {
+		return err
+	}
+
+	item, err := resolvePhotoItem(client, listUUID, args[0])
+	if err != nil {
+		return err
+	}
+
+	imageURL, err := client.SetItemImage(listUUID, item.ItemID, image)
+	if err != nil {
+		return fmt.Errorf("uploading photo: %w", err)
+	}
+
+	if isStructured() {
+		return printOutput(itemPhotoOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Item:     item.ItemID,
+			ImageURL: imageURL,
+		})
+	}
+
+	printSuccess("Attached %s to %s", args[1], item.ItemID)
+	return nil
+}
+
+func runPhotoGet(cmd *cobra.Command, args []string) error {
+	// The image would be mixed with the structured output on stdout
+	if photoFile == "-" && isStructured() {
+		return fmt.Errorf("--file - can't be used with --output %s; save the photo to a file instead", outputMode)
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, photoList)
+	if err != nil {
+		return err
+	}
+
+	item, err := resolvePhotoItem(client, listUUID, args[0])
+	if err != nil {
+		return err
+	}
+
+	details, err := client.GetItemDetails(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching item details: %w", err)
+	}
+	detail := bringapi.FindItemDetail(details, item.ItemID)
+	if detail == nil || detail.ImageURL == "" {
+		return fmt.Errorf("%s has no photo", item.ItemID)
+	}
+
+	image, contentType, err := client.GetItemImage(detail.ImageURL)
+	if err != nil {
+		return fmt.Errorf("downloading photo: %w", err)
+	}
+
+	if photoFile == "-" {
+		_, err := os.Stdout.Write(image)
+		return err
+	}
+
+	output := photoFile
+	if output == "" {
+		ext, ok := photoExtensions[contentType]
+		if !ok {
+			ext = ".jpg"
+		}
+		output = strings.ReplaceAll(item.ItemID, string(os.PathSeparator), "_") + ext
+	}
+	if err := savePhoto(output, image, photoForce); err != nil {
+		return err
+	}
+
+	if isStructured() {
+		return printOutput(itemPhotoOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Item:     item.ItemID,
+			ImageURL: detail.ImageURL,
+			File:     output,
+		})
+	}
+
+	printSuccess("Saved photo of %s to %s", item.ItemID, output)
+	return nil
+}
+
+// savePhoto writes image to path, refusing to replace an existing file
+// unless force is set.
+func savePhoto(path string, image []byte, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", path)
+	}
+	if err != nil {
+		return fmt.Errorf("saving photo: %w", err)
+	}
+	if _, err := f.Write(image); err != nil {
+		f.Close()
+		return fmt.Errorf("saving photo: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("saving photo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSavePhoto(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Kaffee.png")
+	if err := savePhoto(path, []byte("old"), false); err != nil {
+		t.Fatalf("savePhoto() failed: %v", err)
+	}
+
+	err := savePhoto(path, []byte("new"), false)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("savePhoto() over an existing file = %v, want an error suggesting --force", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("file = %q after refusing to overwrite it, want %q", data, "old")
+	}
+
+	if err := savePhoto(path, []byte("new"), true); err != nil {
+		t.Fatalf("savePhoto() with force failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Errorf("file = %q after overwriting it, want %q", data, "new")
+	}
+}
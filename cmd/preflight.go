@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/julianfbeck/bring-cli/internal/match"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+// preflightAdd checks items to add against the current list. Items already
+// on the purchase list are skipped, unless merge approves combining the
+// new specification with the existing one. Items in recently completed are
+// moved back with their UUID.
+func preflightAdd(current *bringapi.ListItemsResponse, args []string, spec string, merge func(item bringapi.ListItem, spec string) bool) ([]bringapi.ItemChange, []bringapi.ItemResult) {
+	var changes []bringapi.ItemChange
+	results := make([]bringapi.ItemResult, 0, len(args))
+	for _, arg := range args {
+		change := bringapi.ItemChange{ItemID: arg, Spec: spec, Operation: bringapi.OperationAdd}
+		result := bringapi.ItemResult{Name: arg, ItemID: arg, Status: bringapi.ItemAdded, Spec: spec}
+
+		if item, location := current.FindItem(arg); item != nil {
+			change.ItemID = item.ItemID
+			change.UUID = item.UUID
+			result.ItemID = item.ItemID
+			result.UUID = item.UUID
+
+			if location == bringapi.LocationPurchase {
+				merged := mergeSpecs(item.Specification, spec)
+				if merged == item.Specification || !merge(*item, spec) {
+					result.Status = bringapi.ItemSkipped
+					result.Spec = item.Specification
+					results = append(results, result)
+					continue
+				}
+				change.Spec = merged
+				result.Spec = merged
+				result.Status = bringapi.ItemMerged
+			}
+		}
+
+		changes = append(changes, change)
+		results = append(results, result)
+	}
+	return changes, results
+}
+
+// preflightComplete resolves items to complete against the purchase list.
+// Items that can't be resolved, or that are already completed, are
+// reported in the results and left out of the changes.
+func preflightComplete(current *bringapi.ListItemsResponse, args []string, matcher bringapi.ItemMatcher) ([]bringapi.ItemChange, []bringapi.ItemResult) {
+	var changes []bringapi.ItemChange
+	results := make([]bringapi.ItemResult, 0, len(args))
+	planned := make(map[string]bool)
+	for _, arg := range args {
+		result := bringapi.ItemResult{Name: arg}
+
+		item, err := matcher(arg, current.Items.Purchase)
+		switch {
+		case errors.Is(err, bringapi.ErrItemNotFound):
+			if done, _ := matcher(arg, current.Items.Recently); done != nil {
+				result.ItemID = done.ItemID
+				result.UUID = done.UUID
+				result.Status = bringapi.ItemSkipped
+				result.Error = "already completed"
+			} else {
+				result.Status = bringapi.ItemNotFound
+				result.Error = err.Error()
+			}
+		case err != nil:
+			result.Status = bringapi.ItemFailed
+			result.Error = err.Error()
+		default:
+			result.ItemID = item.ItemID
+			result.UUID = item.UUID
+			result.Spec = item.Specification
+			result.Status = bringapi.ItemCompleted
+			if key := bringapi.ItemKey(*item); !planned[key] {
+				planned[key] = true
+				changes = append(changes, bringapi.ItemChange{
+					ItemID:    item.ItemID,
+					Spec:      item.Specification,
+					UUID:      item.UUID,
+					Operation: bringapi.OperationComplete,
+				})
+			}
+		}
+
+		results = append(results, result)
+	}
+	return changes, results
+}
+
+// preflightRestore resolves items to move back from recently completed.
+// Items already on the purchase list are skipped; items not on the list
+// are reported as not found.
+func preflightRestore(current *bringapi.ListItemsResponse, args []string) ([]bringapi.ItemChange, []bringapi.ItemResult) {
+	var changes []bringapi.ItemChange
+	results := make([]bringapi.ItemResult, 0, len(args))
+	planned := make(map[string]bool)
+	for _, arg := range args {
+		result := bringapi.ItemResult{Name: arg}
+
+		item, location := current.FindItem(arg)
+		switch location {
+		case bringapi.LocationPurchase:
+			result.ItemID = item.ItemID
+			result.UUID = item.UUID
+			result.Status = bringapi.ItemSkipped
+			result.Error = "already on the purchase list"
+		case bringapi.LocationRecently:
+			result.ItemID = item.ItemID
+			result.UUID = item.UUID
+			result.Spec = item.Specification
+			result.Status = bringapi.ItemRestored
+			if key := bringapi.ItemKey(*item); !planned[key] {
+				planned[key] = true
+				changes = append(changes, bringapi.ItemChange{
+					ItemID:    item.ItemID,
+					Spec:      item.Specification,
+					UUID:      item.UUID,
+					Operation: bringapi.OperationAdd,
+				})
+			}
+		default:
+			result.Status = bringapi.ItemNotFound
+			result.Error = "not in recently completed: " + arg
+		}
+
+		results = append(results, result)
+	}
+	return changes, results
+}
+
+// resultErrors returns an error combining the errors of all failed results,
+// or nil if every item was handled.
+func resultErrors(results []bringapi.ItemResult) error {
+	var errs []error
+	for _, result := range results {
+		if !result.OK() {
+			errs = append(errs, errors.New(result.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resultItems returns the ItemIDs of results with the given statuses.
+func resultItems(results []bringapi.ItemResult, statuses ...string) []string {
+	items := []string{}
+	for _, result := range results {
+		for _, status := range statuses {
+			if result.Status == status {
+				items = append(items, result.ItemID)
+				break
+			}
+		}
+	}
+	return items
+}
+
+// mergeSpecs combines an existing item specification with a new one.
+func mergeSpecs(existing, spec string) string {
+	switch {
+	case existing == "":
+		return spec
+	case spec == "" || match.Fold(existing) == match.Fold(spec):
+		return existing
+	case strings.Contains(match.Fold(existing), match.Fold(spec)):
+		return existing
+	default:
+		return existing + ", " + spec
+	}
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+func testList() *bringapi.ListItemsResponse {
+	list := &bringapi.ListItemsResponse{}
+	list.Items.Purchase = []bringapi.ListItem{
+		{ItemID: "Milch", Specification: "2L", UUID: "a"},
+		{ItemID: "Brot", UUID: "b"},
+	}
+	list.Items.Recently = []bringapi.ListItem{
+		{ItemID: "Butter", UUID: "c"},
+	}
+	return list
+}
+
+// statuses returns the statuses of results.
+func statuses(results []bringapi.ItemResult) []string {
+	s := []string{}
+	for _, result := range results {
+		s = append(s, result.Status)
+	}
+	return s
+}
+
+func TestPreflightAdd(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		spec     string
+		merge    bool
+		changes  []bringapi.ItemChange
+		statuses []string
+	}{
+		{
+			name:     "new item",
+			args:     []string{"Eier"},
+			changes:  []bringapi.ItemChange{{ItemID: "Eier", Operation: bringapi.OperationAdd}},
+			statuses: []string{bringapi.ItemAdded},
+		},
+		{
+			name:     "already on the list",
+			args:     []string{"milch"},
+			spec:     "2l",
+			merge:    true,
+			statuses: []string{bringapi.ItemSkipped},
+		},
+		{
+			name:     "merge declined",
+			args:     []string{"Milch"},
+			spec:     "bio",
+			statuses: []string{bringapi.ItemSkipped},
+		},
+		{
+			name:     "merged",
+			args:     []string{"Milch"},
+			spec:     "bio",
+			merge:    true,
+			changes:  []bringapi.ItemChange{{ItemID: "Milch", Spec: "2L, bio", UUID: "a", Operation: bringapi.OperationAdd}},
+			statuses: []string{bringapi.ItemMerged},
+		},
+		{
+			name:     "recently completed",
+			args:     []string{"butter", "Eier"},
+			changes:  []bringapi.ItemChange{{ItemID: "Butter", UUID: "c", Operation: bringapi.OperationAdd}, {ItemID: "Eier", Operation: bringapi.OperationAdd}},
+			statuses: []string{bringapi.ItemAdded, bringapi.ItemAdded},
+		},
+	}
+
+	for _, tt := range tests {
+		changes, results := preflightAdd(testList(), tt.args, tt.spec, func(bringapi.ListItem, string) bool { return tt.merge })
+		if !reflect.DeepEqual(changes, tt.changes) {
+			t.Errorf("%s: changes = %+v, want %+v", tt.name, changes, tt.changes)
+		}
+		if got := statuses(results); !reflect.DeepEqual(got, tt.statuses) {
+			t.Errorf("%s: statuses = %v, want %v", tt.name, got, tt.statuses)
+		}
+	}
+}
+
+func TestPreflightComplete(t *testing.T) {
+	failing := func(string, []bringapi.ListItem) (*bringapi.ListItem, error) {
+		return nil, errors.New("ambiguous")
+	}
+	complete := bringapi.ItemChange{ItemID: "Milch", Spec: "2L", UUID: "a", Operation: bringapi.OperationComplete}
+
+	tests := []struct {
+		name     string
+		args     []string
+		matcher  bringapi.ItemMatcher
+		changes  []bringapi.ItemChange
+		statuses []string
+	}{
+		{
+			name:     "on the list",
+			args:     []string{"Milch"},
+			changes:  []bringapi.ItemChange{complete},
+			statuses: []string{bringapi.ItemCompleted},
+		},
+		{
+			name:     "named twice",
+			args:     []string{"Milch", "Milch"},
+			changes:  []bringapi.ItemChange{complete},
+			statuses: []string{bringapi.ItemCompleted, bringapi.ItemCompleted},
+		},
+		{
+			name:     "already completed",
+			args:     []string{"Butter"},
+			statuses: []string{bringapi.ItemSkipped},
+		},
+		{
+			name:     "not found",
+			args:     []string{"Käse", "Milch"},
+			changes:  []bringapi.ItemChange{complete},
+			statuses: []string{bringapi.ItemNotFound, bringapi.ItemCompleted},
+		},
+		{
+			name:     "matcher failed",
+			args:     []string{"Milch"},
+			matcher:  failing,
+			statuses: []string{bringapi.ItemFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		matcher := tt.matcher
+		if matcher == nil {
+			matcher = bringapi.MatchExact
+		}
+		changes, results := preflightComplete(testList(), tt.args, matcher)
+		if !reflect.DeepEqual(changes, tt.changes) {
+			t.Errorf("%s: changes = %+v, want %+v", tt.name, changes, tt.changes)
+		}
+		if got := statuses(results); !reflect.DeepEqual(got, tt.statuses) {
+			t.Errorf("%s: statuses = %v, want %v", tt.name, got, tt.statuses)
+		}
+	}
+}
+
+func TestPreflightRestore(t *testing.T) {
+	restore := bringapi.ItemChange{ItemID: "Butter", UUID: "c", Operation: bringapi.OperationAdd}
+
+	tests := []struct {
+		name     string
+		args     []string
+		changes  []bringapi.ItemChange
+		statuses []string
+	}{
+		{
+			name:     "recently completed",
+			args:     []string{"butter", "Butter"},
+			changes:  []bringapi.ItemChange{restore},
+			statuses: []string{bringapi.ItemRestored, bringapi.ItemRestored},
+		},
+		{
+			name:     "on the purchase list",
+			args:     []string{"Milch"},
+			statuses: []string{bringapi.ItemSkipped},
+		},
+		{
+			name:     "not found",
+			args:     []string{"Käse", "Butter"},
+			changes:  []bringapi.ItemChange{restore},
+			statuses: []string{bringapi.ItemNotFound, bringapi.ItemRestored},
+		},
+	}
+
+	for _, tt := range tests {
+		changes, results := preflightRestore(testList(), tt.args)
+		if !reflect.DeepEqual(changes, tt.changes) {
+			t.Errorf("%s: changes = %+v, want %+v", tt.name, changes, tt.changes)
+		}
+		if got := statuses(results); !reflect.DeepEqual(got, tt.statuses) {
+			t.Errorf("%s: statuses = %v, want %v", tt.name, got, tt.statuses)
+		}
+	}
+}
+
+func TestMergeSpecs(t *testing.T) {
+	tests := []struct {
+		existing, spec, want string
+	}{
+		{"", "2L", "2L"},
+		{"2L", "", "2L"},
+		{"2L", "2l", "2L"},
+		{"2 Liter, fettarm", "Fettarm", "2 Liter, fettarm"},
+		{"2L", "bio", "2L, bio"},
+	}
+
+	for _, tt := range tests {
+		if got := mergeSpecs(tt.existing, tt.spec); got != tt.want {
+			t.Errorf("mergeSpecs(%q, %q) = %q, want %q", tt.existing, tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestPreflightCompleteWithoutUUIDs(t *testing.T) {
+	list := &bringapi.ListItemsResponse{}
+	list.Items.Purchase = []bringapi.ListItem{{ItemID: "Eier"}, {ItemID: "Käse"}}
+	want := []bringapi.ItemChange{
+		{ItemID: "Eier", Operation: bringapi.OperationComplete},
+		{ItemID: "Käse", Operation: bringapi.OperationComplete},
+	}
+
+	// complete Eier Käse, and complete --all, which names every item
+	for _, args := range [][]string{{"Eier", "Käse", "Eier"}, {"Eier", "Käse"}} {
+		changes, results := preflightComplete(list, args, bringapi.MatchExact)
+		if !reflect.DeepEqual(changes, want) {
+			t.Errorf("%v: changes = %+v, want %+v", args, changes, want)
+		}
+		for _, result := range results {
+			if result.Status != bringapi.ItemCompleted {
+				t.Errorf("%v: expected %s to be completed, got %s", args, result.Name, result.Status)
+			}
+		}
+	}
+}
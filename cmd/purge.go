@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeList      string
+	purgeOlderThan string
+	purgeDryRun    bool
+)
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge-recently",
+	Short: "Remove recently completed items",
+	Long: `Remove all items from the recently completed section in one batch.
+
+With --older-than, only items completed before that age are removed.
+Completion times come from Bring's activity timeline; items completed
+too long ago to appear there count as old.
+
+If no list is specified, uses the default list.
+
+Examples:
+  bring purge-recently
+  bring purge-recently --older-than 14d
+  bring purge-recently --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runPurge,
+}
+
+func init() {
+	purgeCmd.Flags().StringVarP(&purgeList, "list", "l", "", "target list UUID or name")
+	purgeCmd.Flags().StringVar(&purgeOlderThan, "older-than", "", "only remove items completed longer ago than this (e.g. 7d)")
+	purgeCmd.Flags().BoolVar(&purgeDryRun, "dry-run", false, "show items without removing them")
+	rootCmd.AddCommand(purgeCmd)
+}
+
+func runPurge(cmd *cobra.Command, args []string) error {
+	var cutoff time.Time
+	if purgeOlderThan != "" {
+		age, err := parseDuration(purgeOlderThan)
+		if err != nil {
+			return err
+		}
+		cutoff = time.Now().Add(-age)
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, purgeList)
+	if err != nil {
+		return err
+	}
+
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching list items: %w", err)
+	}
+
+	// Find when each item was last completed
+	completedAt := make(map[string]time.Time)
+	if !cutoff.IsZero() {
+		activity, err := client.GetListActivity(listUUID)
+		if err != nil {
+			return fmt.Errorf("fetching list activity: %w", err)
+		}
+		for _, event := range activity.Timeline {
+			if event.Type != bringapi.ActivityItemsRemoved {
+				continue
+			}
+			for _, item := range event.Content.Items {
+				key := strings.ToLower(item.ItemID)
+				if event.Content.SessionDate.After(completedAt[key]) {
+					completedAt[key] = event.Content.SessionDate
+				}
+			}
+		}
+	}
+
+	var changes []bringapi.ItemChange
+	var purged []string
+	for _, item := range items.Items.Recently {
+		if t, ok := completedAt[strings.ToLower(item.ItemID)]; ok && t.After(cutoff) {
+			continue
+		}
+		changes = append(changes, bringapi.ItemChange{
+			ItemID:    item.ItemID,
+			Spec:      item.Specification,
+			UUID:      item.UUID,
+			Operation: bringapi.OperationRemove,
+		})
+		purged = append(purged, item.ItemID)
+	}
+
+	if len(changes) > 0 && !purgeDryRun {
+		if err := updateItems(client, listUUID, "purge-recently", items, changes); err != nil {
+			return fmt.Errorf("removing items: %w", err)
+		}
+	}
+
+	if isStructured() {
+		return printOutput(itemsChangeOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Items:    nonNil(purged),
+			DryRun:   purgeDryRun,
+		})
+	}
+
+	if len(purged) == 0 {
+		printSuccess("No recently completed items to remove")
+		return nil
+	}
+
+	verb := "Removed"
+	if purgeDryRun {
+		verb = "Would remove"
+	}
+	printSuccess("%s %d recently completed items: %s", verb, len(purged), strings.Join(purged, ", "))
+
+	return nil
+}
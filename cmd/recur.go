@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/internal/config"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recurEvery  string
+	recurSpec   string
+	recurList   string
+	recurDryRun bool
+)
+
+var recurCmd = &cobra.Command{
+	Use:   "recur",
+	Short: "Manage recurring items",
+	Long: `Manage items that are re-added to a list at a fixed interval.
+
+Rules are stored in ~/.config/bring-cli/recurring.yaml and applied by
+'bring recur run', which is meant to be called from cron or a systemd timer.`,
+}
+
+var recurAddCmd = &cobra.Command{
+	Use:   "add <item>",
+	Short: "Add a recurring item",
+	Long: `Add a rule that puts an item on a list at a fixed interval.
+
+Intervals accept Go durations plus days and weeks (e.g. 12h, 7d, 2w).
+Adding a rule for an item that already has one on the same list
+replaces it.
+
+If no list is specified, uses the default list.
+
+Examples:
+  bring recur add Kaffee --every 7d --list Office
+  bring recur add Milch --every 3d --spec "1.5%"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRecurAdd,
+}
+
+var recurListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show recurring items",
+	Long: `Show all recurring item rules and when they are next due.
+
+Example:
+  bring recur list`,
+	Args: cobra.NoArgs,
+	RunE: runRecurList,
+}
+
+var recurRemoveCmd = &cobra.Command{
+	Use:   "remove <item>",
+	Short: "Remove a recurring item",
+	Long: `Remove the recurring rule for an item.
+
+If no list is specified, uses the default list.
+
+Example:
+  bring recur remove Kaffee --list Office`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRecurRemove,
+}
+
+var recurRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Add all due recurring items",
+	Long: `Add all recurring items that are due to their lists.
+
+Due items that are already on the purchase list are not added again,
+but count as done. Last-run timestamps are recorded so repeated runs
+are idempotent.
+
+Example crontab entry:
+  0 * * * * bring recur run --quiet`,
+	Args: cobra.NoArgs,
+	RunE: runRecurRun,
+}
+
+func init() {
+	recurAddCmd.Flags().StringVarP(&recurEvery, "every", "e", "", "interval between additions (e.g. 7d)")
+	recurAddCmd.Flags().StringVarP(&recurSpec, "spec", "s", "", "item specification (quantity, notes)")
+	recurAddCmd.Flags().StringVarP(&recurList, "list", "l", "", "target list UUID or name")
+	_ = recurAddCmd.MarkFlagRequired("every")
+	recurRemoveCmd.Flags().StringVarP(&recurList, "list", "l", "", "target list UUID or name")
+	recurRunCmd.Flags().BoolVar(&recurDryRun, "dry-run", false, "show due items without adding them")
+
+	rootCmd.AddCommand(recurCmd)
+	recurCmd.AddCommand(recurAddCmd)
+	recurCmd.AddCommand(recurListCmd)
+	recurCmd.AddCommand(recurRemoveCmd)
+	recurCmd.AddCommand(recurRunCmd)
+}
+
+func runRecurAdd(cmd *cobra.Command, args []string) error {
+	if _, err := parseDuration(recurEvery); err != nil {
+		return err
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, recurList)
+	if err != nil {
+		return err
+	}
+
+	rules, err := config.LoadRecurringRules()
+	if err != nil {
+		return err
+	}
+
+	rule := &config.RecurringRule{
+		ItemID:   args[0],
+		Spec:     recurSpec,
+		ListUUID: listUUID,
+		Every:    recurEvery,
+	}
+
+	replaced := false
+	for i, existing := range rules {
+		if existing.Matches(rule.ItemID, listUUID) {
+			rules[i] = rule
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		rules = append(rules, rule)
+	}
+
+	if err := config.SaveRecurringRules(rules); err != nil {
+		return err
+	}
+
+	if isStructured() {
+		return printOutput(recurOutput{envelope: newEnvelope(true), Rule: newOutputRule(rule)})
+	}
+
+	printSuccess("%s will be added every %s", rule.ItemID, rule.Every)
+	return nil
+}
+
+func runRecurList(cmd *cobra.Command, args []string) error {
+	rules, err := config.LoadRecurringRules()
+	if err != nil {
+		return err
+	}
+
+	if isStructured() {
+		return printOutput(recurListOutput{envelope: newEnvelope(true), Rules: newOutputRules(rules)})
+	}
+
+	if len(rules) == 0 {
+		fmt.Println("No recurring items")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ITEM\tSPECIFICATION\tEVERY\tLIST\tNEXT")
+	for _, rule := range rules {
+		next := "now"
+		if every, err := parseDuration(rule.Every); err != nil {
+			next = "invalid interval"
+		} else if !rule.LastRun.IsZero() && rule.LastRun.Add(every).After(time.Now()) {
+			next = rule.LastRun.Add(every).Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", rule.ItemID, rule.Spec, rule.Every, rule.ListUUID, next)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func runRecurRemove(cmd *cobra.Command, args []string) error {
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, recurList)
+	if err != nil {
+		return err
+	}
+
+	rules, err := config.LoadRecurringRules()
+	if err != nil {
+		return err
+	}
+
+	var kept []*config.RecurringRule
+	for _, rule := range rules {
+		if !rule.Matches(args[0], listUUID) {
+			kept = append(kept, rule)
+		}
+	}
+	if len(kept) == len(rules) {
+		return fmt.Errorf("no recurring rule for %s on list %s", args[0], listUUID)
+	}
+
+	if err := config.SaveRecurringRules(kept); err != nil {
+		return err
+	}
+
+	if isStructured() {
+		return printOutput(recurRemoveOutput{envelope: newEnvelope(true), Item: args[0], List: listUUID})
+	}
+
+	printSuccess("Removed recurring item %s", args[0])
+	return nil
+}
+
+func runRecurRun(cmd *cobra.Command, args []string) error {
+	rules, err := config.LoadRecurringRules()
+	if err != nil {
+		return err
+	}
+
+	// Group due rules by list so each list gets a single batch
+	now := time.Now()
+	due := make(map[string][]*config.RecurringRule)
+	var listOrder []string
+	for _, rule := range rules {
+		every, err := parseDuration(rule.Every)
+		if err != nil {
+			return fmt.Errorf("rule for %s: %w", rule.ItemID, err)
+		}
+		if !rule.LastRun.IsZero() && now.Before(rule.LastRun.Add(every)) {
+			continue
+		}
+		if _, ok := due[rule.ListUUID]; !ok {
+			listOrder = append(listOrder, rule.ListUUID)
+		}
+		due[rule.ListUUID] = append(due[rule.ListUUID], rule)
+	}
+
+	var added, skipped []string
+	if len(listOrder) > 0 {
+		client, err := getAuthenticatedClient()
+		if err != nil {
+			return err
+		}
+
+		for _, listUUID := range listOrder {
+			items, err := client.GetListItems(listUUID)
+			if err != nil {
+				return fmt.Errorf("fetching list items: %w", err)
+			}
+
+			onList := make(map[string]bool)
+			for _, item := range items.Items.Purchase {
+				onList[strings.ToLower(item.ItemID)] = true
+			}
+
+			var changes []bringapi.ItemChange
+			for _, rule := range due[listUUID] {
+				if onList[strings.ToLower(rule.ItemID)] {
+					skipped = append(skipped, rule.ItemID)
+					continue
+				}
+				changes = append(changes, bringapi.ItemChange{
+					ItemID:    rule.ItemID,
+					Spec:      rule.Spec,
+					Operation: bringapi.OperationAdd,
+				})
+				added = append(added, rule.ItemID)
+			}
+
+			if recurDryRun {
+				continue
+			}
+
+			if len(changes) > 0 {
+				if err := updateItems(client, listUUID, "recur run", items, changes); err != nil {
+					return fmt.Errorf("adding items: %w", err)
+				}
+			}
+
+			// Record the run only once the list has been updated
+			for _, rule := range due[listUUID] {
+				rule.LastRun = now
+			}
+			if err := config.SaveRecurringRules(rules); err != nil {
+				return err
+			}
+		}
+	}
+
+	if isStructured() {
+		return printOutput(recurRunOutput{
+			envelope: newEnvelope(true),
+			DryRun:   recurDryRun,
+			Added:    nonNil(added),
+			Skipped:  nonNil(skipped),
+		})
+	}
+
+	if len(added) == 0 && len(skipped) == 0 {
+		printSuccess("No recurring items due")
+		return nil
+	}
+
+	verb := "Added"
+	if recurDryRun {
+		verb = "Would add"
+	}
+	printSuccess("%s %d recurring items: %s", verb, len(added), strings.Join(added, ", "))
+	if len(skipped) > 0 {
+		printSuccess("Already on the list: %s", strings.Join(skipped, ", "))
+	}
+
+	return nil
+}
+
+// parseDuration parses a Go duration, extended with "d" (days) and
+// "w" (weeks) suffixes for whole numbers, e.g. "7d" or "2w".
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if !strings.HasSuffix(s, suffix) {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSuffix(s, suffix)); err == nil {
+			if n <= 0 {
+				return 0, fmt.Errorf("invalid duration: %s (must be positive)", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s (use e.g. 12h, 7d, 2w)", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid duration: %s (must be positive)", s)
+	}
+	return d, nil
+}
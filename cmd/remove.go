@@ -1,24 +1,40 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/julianfbeck/bring-cli/internal/api"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"github.com/spf13/cobra"
 )
 
-var removeList string
+var (
+	removeList   string
+	removeExact  bool
+	removeNotify bool
+)
 
 var removeCmd = &cobra.Command{
 	Use:   "remove <item>...",
 	Short: "Remove item(s) from a shopping list",
 	Long: `Remove one or more items from a shopping list.
 
+Items are matched against the list ignoring case and accents, by their
+localized name (see BRING_LOCALE) and with small typos. Use --exact to
+only accept exact item names.
+
+All items found are removed in one batch. Items that are not on the
+list are reported, and the command exits with an error.
+
+--notify sends a changed-list notification to the other list members
+afterwards (see bring config notify to do so automatically).
+
 If no list is specified, uses the default list.
 
 Examples:
   bring remove Milk
+  bring remove kase
   bring remove Eggs Butter Cheese
   bring remove "Orange Juice" --list abc123`,
 	Args: cobra.MinimumNArgs(1),
@@ -26,7 +42,9 @@ Examples:
 }
 
 func init() {
-	removeCmd.Flags().StringVarP(&removeList, "list", "l", "", "target list UUID")
+	removeCmd.Flags().StringVarP(&removeList, "list", "l", "", "target list UUID or name")
+	removeCmd.Flags().BoolVar(&removeExact, "exact", false, "only remove items whose name matches exactly")
+	removeCmd.Flags().BoolVarP(&removeNotify, "notify", "n", false, "notify list members about the change")
 	rootCmd.AddCommand(removeCmd)
 }
 
@@ -36,37 +54,66 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	listUUID, err := getDefaultListUUID(removeList)
+	listUUID, err := resolveListUUID(client, removeList)
 	if err != nil {
 		return err
 	}
 
-	// Build changes for all items
-	var changes []api.ItemChange
-	for _, item := range args {
-		changes = append(changes, api.ItemChange{
-			ItemID:    item,
-			Operation: api.OperationRemove,
-		})
+	current, err := client.GetListItems(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching list items: %w", err)
+	}
+
+	matcher := bringapi.MatchExact
+	if !removeExact {
+		matcher = (&itemResolver{client: client}).resolve
+	}
+
+	changes, results := bringapi.PlanRemoval(current, args, matcher)
+	if len(changes) > 0 {
+		if err := updateItems(client, listUUID, "remove", current, changes); err != nil {
+			return fmt.Errorf("removing items: %w", err)
+		}
 	}
 
-	if err := client.UpdateItems(listUUID, changes); err != nil {
-		return fmt.Errorf("removing items: %w", err)
+	var removed []string
+	var errs []error
+	for _, result := range results {
+		if result.Status == bringapi.ItemRemoved {
+			removed = append(removed, result.ItemID)
+		} else {
+			errs = append(errs, errors.New(result.Error))
+		}
 	}
 
-	if isJSON() {
-		return printJSON(map[string]interface{}{
-			"success": true,
-			"items":   args,
-			"list":    listUUID,
-		})
+	notify := changeNotification(cmd, removeNotify, false)
+	notified := ""
+	if len(removed) > 0 {
+		notified = notifyChange(client, listUUID, notify, nil)
 	}
 
-	if len(args) == 1 {
-		printSuccess("Removed %s from list", args[0])
-	} else {
-		printSuccess("Removed %d items from list: %s", len(args), strings.Join(args, ", "))
+	if isStructured() {
+		if err := printOutput(itemsChangeOutput{
+			envelope: newEnvelope(len(errs) == 0),
+			List:     listUUID,
+			Items:    nonNil(removed),
+			Results:  newItemResults(results),
+			Notified: notified,
+		}); err != nil {
+			return err
+		}
+		return reported(errors.Join(errs...))
+	}
+
+	if len(removed) == 1 {
+		printSuccess("Removed %s from list", removed[0])
+	} else if len(removed) > 1 {
+		printSuccess("Removed %d items from list: %s", len(removed), strings.Join(removed, ", "))
+	}
+	if notificationSent(notified) {
+		printSuccess("Notified list users: %s", notify.describe(nil))
 	}
 
-	return nil
+	// Exit non-zero if any item could not be removed
+	return errors.Join(errs...)
 }
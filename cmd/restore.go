@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var restoreList string
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore-item <item>...",
+	Short: "Move recently completed item(s) back to the purchase list",
+	Long: `Move one or more items from the recently completed section back to
+the purchase list, keeping their specification. Items that aren't in
+recently completed are reported, and the others are still restored.
+
+If no list is specified, uses the default list.
+
+Examples:
+  bring restore-item Milk
+  bring restore-item Eggs Butter --list abc123`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	restoreCmd.Flags().StringVarP(&restoreList, "list", "l", "", "target list UUID or name")
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, restoreList)
+	if err != nil {
+		return err
+	}
+
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching list items: %w", err)
+	}
+
+	changes, results := preflightRestore(items, args)
+	if len(changes) > 0 {
+		if err := updateItems(client, listUUID, "restore-item", items, changes); err != nil {
+			return fmt.Errorf("restoring items: %w", err)
+		}
+	}
+
+	restored := resultItems(results, bringapi.ItemRestored)
+	skipped := resultItems(results, bringapi.ItemSkipped)
+	failed := resultErrors(results)
+
+	if isStructured() {
+		if err := printOutput(itemsChangeOutput{
+			envelope: newEnvelope(failed == nil),
+			List:     listUUID,
+			Items:    nonNil(restored),
+			Results:  newItemResults(results),
+		}); err != nil {
+			return err
+		}
+		return reported(failed)
+	}
+
+	switch {
+	case len(restored) == 1:
+		printSuccess("Restored %s to the purchase list", restored[0])
+	case len(restored) > 1:
+		printSuccess("Restored %d items to the purchase list: %s", len(restored), strings.Join(restored, ", "))
+	}
+	if len(skipped) > 0 {
+		printWarning("already on the purchase list: %s", strings.Join(skipped, ", "))
+	}
+
+	return failed
+}
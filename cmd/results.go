@@ -0,0 +1,513 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/julianfbeck/bring-cli/internal/config"
+	"github.com/julianfbeck/bring-cli/internal/journal"
+	"github.com/julianfbeck/bring-cli/internal/stats"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+// The types in this file are the JSON output of the commands. They are
+// decoupled from the API types, so the output only changes on purpose:
+// fields may be added, but removing or changing one requires bumping
+// schemaVersion. bring schema prints their JSON Schema.
+
+// schemaVersion is the version of the JSON output.
+const schemaVersion = 1
+
+// envelope is part of every JSON output.
+type envelope struct {
+	SchemaVersion int  `json:"schemaVersion"`
+	Success       bool `json:"success"`
+}
+
+// newEnvelope returns the envelope of an output.
+func newEnvelope(success bool) envelope {
+	return envelope{SchemaVersion: schemaVersion, Success: success}
+}
+
+// errorOutput is printed to stderr when a command fails.
+type errorOutput struct {
+	envelope
+	Error string `json:"error"`
+}
+
+// outputItem is an item on a list. HasPhoto is only set by list.
+type outputItem struct {
+	ItemID        string   `json:"itemId"`
+	Specification string   `json:"specification"`
+	UUID          string   `json:"uuid"`
+	Flags         []string `json:"flags,omitempty"`
+	HasPhoto      bool     `json:"hasPhoto,omitempty"`
+}
+
+// outputList is a shopping list.
+type outputList struct {
+	ListUUID string `json:"listUuid"`
+	Name     string `json:"name"`
+	Theme    string `json:"theme"`
+}
+
+// outputMember is a member of a list.
+type outputMember struct {
+	PublicUUID string `json:"publicUuid"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+}
+
+// itemResult is the outcome of a change to a single item; Status is one
+// of added, merged, completed, removed, flagged, restored, skipped,
+// not_found and failed.
+type itemResult struct {
+	Name   string   `json:"name"`
+	ItemID string   `json:"itemId,omitempty"`
+	UUID   string   `json:"uuid,omitempty"`
+	Status string   `json:"status"`
+	Spec   string   `json:"spec,omitempty"`
+	Flags  []string `json:"flags,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// outputChange is a change made to an item, as recorded in the journal.
+type outputChange struct {
+	Operation string `json:"operation"`
+	ItemID    string `json:"itemId"`
+	Spec      string `json:"spec,omitempty"`
+	UUID      string `json:"uuid,omitempty"`
+}
+
+// listItemsOutput is the output of list.
+type listItemsOutput struct {
+	envelope
+	List  string    `json:"list"`
+	Items listItems `json:"items"`
+}
+
+type listItems struct {
+	Purchase []outputItem `json:"purchase"`
+	Recently []outputItem `json:"recently"`
+}
+
+// listsOutput is the output of lists.
+type listsOutput struct {
+	envelope
+	Lists []outputList `json:"lists"`
+}
+
+// listChangeOutput is the output of lists create, rename, theme and
+// delete, and of config set-list.
+type listChangeOutput struct {
+	envelope
+	List     outputList `json:"list"`
+	Previous string     `json:"previous,omitempty"`
+}
+
+// itemsChangeOutput is the output of commands changing items: add,
+// complete, remove, flag, restore and purge. Items lists the items that
+// were changed; Notified is the notification sent afterwards, or
+// "debounced" if it was skipped because it was sent recently.
+type itemsChangeOutput struct {
+	envelope
+	List     string       `json:"list"`
+	Items    []string     `json:"items"`
+	Results  []itemResult `json:"results,omitempty"`
+	DryRun   bool         `json:"dryRun,omitempty"`
+	Notified string       `json:"notified,omitempty"`
+}
+
+// membersOutput is the output of members.
+type membersOutput struct {
+	envelope
+	List    string         `json:"list"`
+	Members []outputMember `json:"members"`
+}
+
+// memberChangeOutput is the output of members invite and remove.
+type memberChangeOutput struct {
+	envelope
+	List   string       `json:"list"`
+	Member outputMember `json:"member"`
+}
+
+// activityOutput is the output of activity.
+type activityOutput struct {
+	envelope
+	List   string          `json:"list"`
+	Events []activityEvent `json:"events"`
+}
+
+// statsOutput is the output of stats.
+type statsOutput struct {
+	envelope
+	List   string      `json:"list"`
+	Since  time.Time   `json:"since"`
+	Source string      `json:"source"`
+	Stats  outputStats `json:"stats"`
+}
+
+// outputStats is the aggregated statistics of the purchases of a list.
+type outputStats struct {
+	Total        int               `json:"total"`
+	Items        []outputItemStats `json:"items"`
+	Days         []outputDayStats  `json:"days"`
+	Contributors []outputUserStats `json:"contributors"`
+}
+
+// outputItemStats summarizes the purchases of a single item.
+type outputItemStats struct {
+	ItemID              string    `json:"itemId"`
+	Count               int       `json:"count"`
+	FirstPurchase       time.Time `json:"firstPurchase"`
+	LastPurchase        time.Time `json:"lastPurchase"`
+	AverageIntervalDays float64   `json:"averageIntervalDays,omitempty"`
+}
+
+// outputDayStats counts purchases made on a day of the week.
+type outputDayStats struct {
+	Day   string `json:"day"`
+	Count int    `json:"count"`
+}
+
+// outputUserStats counts purchases made by a list member.
+type outputUserStats struct {
+	User  string `json:"user"`
+	Count int    `json:"count"`
+}
+
+// suggestOutput is the output of suggest.
+type suggestOutput struct {
+	envelope
+	List        string             `json:"list"`
+	Suggestions []outputSuggestion `json:"suggestions"`
+	Added       []string           `json:"added"`
+}
+
+// outputSuggestion is an item predicted to be due for repurchase.
+type outputSuggestion struct {
+	ItemID              string    `json:"itemId"`
+	LastPurchase        time.Time `json:"lastPurchase"`
+	Purchases           int       `json:"purchases"`
+	AverageIntervalDays float64   `json:"averageIntervalDays"`
+	DaysSinceLast       float64   `json:"daysSinceLast"`
+	Confidence          float64   `json:"confidence"`
+}
+
+// notifyOutput is the output of notify.
+type notifyOutput struct {
+	envelope
+	List     string   `json:"list"`
+	Type     string   `json:"type"`
+	Items    []string `json:"items,omitempty"`
+	Reaction string   `json:"reaction,omitempty"`
+	Activity string   `json:"activity,omitempty"`
+}
+
+// itemPhotoOutput is the output of photo set and get.
+type itemPhotoOutput struct {
+	envelope
+	List     string `json:"list"`
+	Item     string `json:"item"`
+	ImageURL string `json:"imageUrl"`
+	File     string `json:"file,omitempty"`
+}
+
+// historyOutput is the output of history.
+type historyOutput struct {
+	envelope
+	Records []outputRecord `json:"records"`
+}
+
+// outputRecord is the result of a change to an item, as recorded in the
+// journal.
+type outputRecord struct {
+	Time      time.Time `json:"time"`
+	Profile   string    `json:"profile,omitempty"`
+	List      string    `json:"list"`
+	Command   string    `json:"command"`
+	Operation string    `json:"operation"`
+	ItemID    string    `json:"itemId"`
+	Spec      string    `json:"spec,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// undoOutput is the output of undo.
+type undoOutput struct {
+	envelope
+	DryRun bool          `json:"dryRun"`
+	Undone []undoneEntry `json:"undone"`
+}
+
+// undoneEntry is a journal entry reverted by undo.
+type undoneEntry struct {
+	ID      string         `json:"id"`
+	Command string         `json:"command"`
+	List    string         `json:"list"`
+	Time    time.Time      `json:"time"`
+	Changes []outputChange `json:"changes"`
+}
+
+// templateOutput is the output of template create and template show
+// with a name.
+type templateOutput struct {
+	envelope
+	Template outputItemTemplate `json:"template"`
+}
+
+// outputItemTemplate is a template, a named bundle of items.
+type outputItemTemplate struct {
+	Name  string               `json:"name"`
+	Items []outputTemplateItem `json:"items"`
+}
+
+// outputTemplateItem is an item of a template.
+type outputTemplateItem struct {
+	ItemID string `json:"itemId"`
+	Spec   string `json:"spec,omitempty"`
+}
+
+// templatesOutput is the output of template show.
+type templatesOutput struct {
+	envelope
+	Templates []outputItemTemplate `json:"templates"`
+}
+
+// templateApplyOutput is the output of template apply.
+type templateApplyOutput struct {
+	envelope
+	Template string   `json:"template"`
+	List     string   `json:"list"`
+	Added    []string `json:"added"`
+	Skipped  []string `json:"skipped"`
+}
+
+// templateDeleteOutput is the output of template delete.
+type templateDeleteOutput struct {
+	envelope
+	Template string `json:"template"`
+}
+
+// recurOutput is the output of recur add.
+type recurOutput struct {
+	envelope
+	Rule outputRule `json:"rule"`
+}
+
+// outputRule is a recurring item. LastRun is nil until the rule first ran.
+type outputRule struct {
+	ItemID  string     `json:"itemId"`
+	Spec    string     `json:"spec,omitempty"`
+	List    string     `json:"list"`
+	Every   string     `json:"every"`
+	LastRun *time.Time `json:"lastRun,omitempty"`
+}
+
+// recurListOutput is the output of recur list.
+type recurListOutput struct {
+	envelope
+	Rules []outputRule `json:"rules"`
+}
+
+// recurRemoveOutput is the output of recur remove.
+type recurRemoveOutput struct {
+	envelope
+	Item string `json:"item"`
+	List string `json:"list"`
+}
+
+// recurRunOutput is the output of recur run.
+type recurRunOutput struct {
+	envelope
+	DryRun  bool     `json:"dryRun"`
+	Added   []string `json:"added"`
+	Skipped []string `json:"skipped"`
+}
+
+// loginOutput is the output of login.
+type loginOutput struct {
+	envelope
+	Email       string `json:"email"`
+	DefaultList string `json:"defaultList"`
+}
+
+// notifySettingsOutput is the output of config notify.
+type notifySettingsOutput struct {
+	envelope
+	Changes      bool   `json:"changes"`
+	ShoppingDone bool   `json:"shoppingDone"`
+	Debounce     string `json:"debounce"`
+}
+
+// newOutputItem converts an item on a list.
+func newOutputItem(item bringapi.ListItem) outputItem {
+	return outputItem{
+		ItemID:        item.ItemID,
+		Specification: item.Specification,
+		UUID:          item.UUID,
+		Flags:         item.Conditions().Badges(),
+	}
+}
+
+// newOutputItems converts items on a list, never returning nil so the
+// JSON has an empty array.
+func newOutputItems(items []bringapi.ListItem) []outputItem {
+	converted := make([]outputItem, 0, len(items))
+	for _, item := range items {
+		converted = append(converted, newOutputItem(item))
+	}
+	return converted
+}
+
+// newOutputList converts a shopping list.
+func newOutputList(list bringapi.ShoppingList) outputList {
+	return outputList{ListUUID: list.ListUUID, Name: list.Name, Theme: list.Theme}
+}
+
+// newOutputMember converts a list member.
+func newOutputMember(user bringapi.ListUser) outputMember {
+	return outputMember{PublicUUID: user.PublicUUID, Name: user.Name, Email: user.Email}
+}
+
+// newItemResults converts per-item results.
+func newItemResults(results []bringapi.ItemResult) []itemResult {
+	converted := make([]itemResult, 0, len(results))
+	for _, r := range results {
+		converted = append(converted, itemResult{
+			Name:   r.Name,
+			ItemID: r.ItemID,
+			UUID:   r.UUID,
+			Status: r.Status,
+			Spec:   r.Spec,
+			Error:  r.Error,
+		})
+	}
+	return converted
+}
+
+// newOutputChanges converts item changes.
+func newOutputChanges(changes []bringapi.ItemChange) []outputChange {
+	converted := make([]outputChange, 0, len(changes))
+	for _, change := range changes {
+		converted = append(converted, outputChange{
+			Operation: journal.OperationName(change.Operation),
+			ItemID:    change.ItemID,
+			Spec:      change.Spec,
+			UUID:      change.UUID,
+		})
+	}
+	return converted
+}
+
+// newOutputStats converts purchase statistics.
+func newOutputStats(report *stats.Report) outputStats {
+	converted := outputStats{
+		Total:        report.Total,
+		Items:        make([]outputItemStats, 0, len(report.Items)),
+		Days:         make([]outputDayStats, 0, len(report.Days)),
+		Contributors: make([]outputUserStats, 0, len(report.Contributors)),
+	}
+	for _, item := range report.Items {
+		converted.Items = append(converted.Items, outputItemStats{
+			ItemID:              item.ItemID,
+			Count:               item.Count,
+			FirstPurchase:       item.FirstPurchase,
+			LastPurchase:        item.LastPurchase,
+			AverageIntervalDays: item.AverageIntervalDays,
+		})
+	}
+	for _, day := range report.Days {
+		converted.Days = append(converted.Days, outputDayStats{Day: day.Day, Count: day.Count})
+	}
+	for _, user := range report.Contributors {
+		converted.Contributors = append(converted.Contributors, outputUserStats{User: user.User, Count: user.Count})
+	}
+	return converted
+}
+
+// newOutputSuggestions converts repurchase suggestions.
+func newOutputSuggestions(suggestions []stats.Suggestion) []outputSuggestion {
+	converted := make([]outputSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		converted = append(converted, outputSuggestion{
+			ItemID:              s.ItemID,
+			LastPurchase:        s.LastPurchase,
+			Purchases:           s.Purchases,
+			AverageIntervalDays: s.AverageIntervalDays,
+			DaysSinceLast:       s.DaysSinceLast,
+			Confidence:          s.Confidence,
+		})
+	}
+	return converted
+}
+
+// newOutputRecords converts journal records.
+func newOutputRecords(records []journal.Record) []outputRecord {
+	converted := make([]outputRecord, 0, len(records))
+	for _, r := range records {
+		converted = append(converted, outputRecord{
+			Time:      r.Time,
+			Profile:   r.Profile,
+			List:      r.ListUUID,
+			Command:   r.Command,
+			Operation: r.Operation,
+			ItemID:    r.ItemID,
+			Spec:      r.Spec,
+			Result:    r.Result,
+			Error:     r.Error,
+		})
+	}
+	return converted
+}
+
+// newOutputTemplate converts a template.
+func newOutputTemplate(tmpl *config.Template) outputItemTemplate {
+	converted := outputItemTemplate{Name: tmpl.Name, Items: make([]outputTemplateItem, 0, len(tmpl.Items))}
+	for _, item := range tmpl.Items {
+		converted.Items = append(converted.Items, outputTemplateItem{ItemID: item.ItemID, Spec: item.Spec})
+	}
+	return converted
+}
+
+// newOutputTemplates converts templates.
+func newOutputTemplates(templates []*config.Template) []outputItemTemplate {
+	converted := make([]outputItemTemplate, 0, len(templates))
+	for _, tmpl := range templates {
+		converted = append(converted, newOutputTemplate(tmpl))
+	}
+	return converted
+}
+
+// newOutputRule converts a recurring rule.
+func newOutputRule(rule *config.RecurringRule) outputRule {
+	converted := outputRule{
+		ItemID: rule.ItemID,
+		Spec:   rule.Spec,
+		List:   rule.ListUUID,
+		Every:  rule.Every,
+	}
+	if !rule.LastRun.IsZero() {
+		lastRun := rule.LastRun
+		converted.LastRun = &lastRun
+	}
+	return converted
+}
+
+// newOutputRules converts recurring rules.
+func newOutputRules(rules []*config.RecurringRule) []outputRule {
+	converted := make([]outputRule, 0, len(rules))
+	for _, rule := range rules {
+		converted = append(converted, newOutputRule(rule))
+	}
+	return converted
+}
+
+// nonNil returns s, or an empty slice instead of nil so the JSON has an
+// empty array.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
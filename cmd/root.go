@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
@@ -13,6 +17,11 @@ var (
 	quiet     bool
 	jsonOut   bool
 	noColor   bool
+
+	outputFlag string
+	formatFlag string
+	queryFlag  string
+	ndjsonFlag bool
 )
 
 var rootCmd = &cobra.Command{
@@ -29,53 +38,140 @@ Get started:
   bring add Milk        # Add an item to your default list`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		outputReady = true
+		if err := setupOutput(); err != nil {
+			return err
+		}
+		if isNDJSON() {
+			startStream(cmd, args)
+		}
+		return nil
+	},
 }
 
+// outputReady is set once the output was set up for the command being run.
+var outputReady bool
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		printError(err)
+	registerFlagCompletions(rootCmd)
+	if err := execute(os.Args[1:]); err != nil {
 		os.Exit(1)
 	}
 }
 
+// execute runs the command given by args and reports its error, if any.
+func execute(args []string) error {
+	rootCmd.SetArgs(args)
+	cmd, err := rootCmd.ExecuteC()
+	if err == nil {
+		return nil
+	}
+
+	if !outputReady {
+		setupFailedOutput(cmd, args)
+	}
+	var r reportedError
+	if !errors.As(err, &r) {
+		printError(err)
+	}
+	return err
+}
+
+// setupFailedOutput sets up the output of a command that failed before
+// it ran, e.g. on invalid arguments or flags, so the error is reported in
+// the requested format, starting the stream for --ndjson. Flags are
+// parsed again, ignoring unknown ones, since parsing stops at the first
+// invalid flag.
+func setupFailedOutput(cmd *cobra.Command, args []string) {
+	if _, rest, err := rootCmd.Find(args); err == nil {
+		args = rest
+	}
+	cmd.FParseErrWhitelist.UnknownFlags = true
+	_ = cmd.ParseFlags(args)
+	// An invalid output setup leaves the mode it got to, which is
+	// all there is to report its error in.
+	_ = setupOutput()
+	if isNDJSON() {
+		startStream(cmd, cmd.Flags().Args())
+	}
+}
+
+// reportedError is an error already reported in the structured output of
+// a command, e.g. as failed results, so Execute only sets the exit code.
+type reportedError struct {
+	err error
+}
+
+func (e reportedError) Error() string { return e.err.Error() }
+func (e reportedError) Unwrap() error { return e.err }
+
+// reported marks err as reported in the structured output. It returns nil
+// for a nil error.
+func reported(err error) error {
+	if err == nil {
+		return nil
+	}
+	return reportedError{err}
+}
+
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress non-essential output")
-	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "output as JSON")
-	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable color output")
+	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "output as JSON (same as --output json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "output format: table, json, yaml, csv, tsv or plain")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "", "format each result with a Go template, e.g. '{{.ItemID}}'")
+	rootCmd.PersistentFlags().StringVar(&queryFlag, "query", "", "filter JSON output with a jq expression, e.g. '.items.purchase[].itemId'")
+	rootCmd.PersistentFlags().StringVar(&queryFlag, "jq", "", "same as --query")
+	rootCmd.PersistentFlags().BoolVar(&ndjsonFlag, "ndjson", false, "stream events as newline-delimited JSON on stdout")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable color output (also NO_COLOR)")
 
 	rootCmd.Version = version
 	rootCmd.SetVersionTemplate("bring version {{.Version}}\n")
 }
 
-// printError prints an error message to stderr.
+// printError prints an error message to stderr, or emits an error event
+// with --ndjson.
 func printError(err error) {
-	if jsonOut {
-		_ = json.NewEncoder(os.Stderr).Encode(map[string]string{"error": err.Error()})
+	if isNDJSON() {
+		emitEvent(streamEvent{Event: eventError, Message: err.Error()})
+	} else if isStructured() {
+		_ = json.NewEncoder(os.Stderr).Encode(errorOutput{envelope: newEnvelope(false), Error: err.Error()})
 	} else {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintln(os.Stderr, colorize(os.Stderr, roleError, fmt.Sprintf("Error: %v", err)))
 	}
 }
 
 // printSuccess prints a success message if not in quiet mode.
 func printSuccess(format string, args ...interface{}) {
-	if !quiet && !jsonOut {
-		fmt.Printf(format+"\n", args...)
+	if !quiet && !isStructured() {
+		fmt.Println(style(roleSuccess, fmt.Sprintf(format, args...)))
 	}
 }
 
-// printJSON prints data as JSON.
-func printJSON(v interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(v)
+// printWarning prints a warning to stderr if not in quiet mode. With
+// --ndjson, warnings are always emitted as events.
+func printWarning(format string, args ...interface{}) {
+	if isNDJSON() {
+		emitEvent(streamEvent{Event: eventWarning, Message: fmt.Sprintf(format, args...)})
+	} else if !quiet {
+		fmt.Fprintln(os.Stderr, colorize(os.Stderr, roleWarning, fmt.Sprintf("Warning: "+format, args...)))
+	}
+}
+
+// confirm asks a yes/no question on the terminal and reports whether the
+// user answered yes. It returns false without asking if stdin is not a
+// terminal or structured output is requested.
+func confirm(format string, args ...interface{}) bool {
+	if isStructured() || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+	fmt.Printf(format+" [y/N]: ", args...)
+	response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
 }
 
 // isQuiet returns true if output should be suppressed.
 func isQuiet() bool {
 	return quiet
 }
-
-// isJSON returns true if JSON output is requested.
-func isJSON() bool {
-	return jsonOut
-}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// capture runs f and returns what it wrote to stdout and stderr.
+func capture(t *testing.T, f func()) (stdout, stderr string) {
+	t.Helper()
+	read := func(file **os.File) func() string {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig := *file
+		*file = w
+		done := make(chan string)
+		go func() {
+			data, _ := io.ReadAll(r)
+			done <- string(data)
+		}()
+		return func() string {
+			*file = orig
+			w.Close()
+			return <-done
+		}
+	}
+	outDone, errDone := read(&os.Stdout), read(&os.Stderr)
+	f()
+	return outDone(), errDone()
+}
+
+// resetOutput resets the output flags and mode between commands.
+func resetOutput() {
+	jsonOut, ndjsonFlag = false, false
+	outputFlag, formatFlag, queryFlag = "", "", ""
+	outputMode, outputReady = outputTable, false
+}
+
+func TestExecuteReportsEarlyErrorsAsJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		error string
+	}{
+		{"arg count", []string{"lists", "rename", "--json", "x"}, "accepts 2 arg(s), received 1"},
+		{"unknown flag", []string{"lists", "--json", "--bogus"}, "unknown flag: --bogus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetOutput()
+			defer resetOutput()
+
+			var err error
+			stdout, stderr := capture(t, func() { err = execute(tt.args) })
+			if err == nil {
+				t.Fatal("execute() succeeded, want an error")
+			}
+			if stdout != "" {
+				t.Errorf("stdout = %q, want nothing", stdout)
+			}
+			var out struct {
+				Success bool   `json:"success"`
+				Error   string `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(stderr), &out); err != nil {
+				t.Fatalf("stderr is not JSON: %v\n%s", err, stderr)
+			}
+			if out.Success || !strings.Contains(out.Error, tt.error) {
+				t.Errorf("stderr = %s, want a failure containing %q", stderr, tt.error)
+			}
+		})
+	}
+}
+
+func TestExecuteStreamsEarlyErrors(t *testing.T) {
+	resetOutput()
+	defer resetOutput()
+
+	var err error
+	stdout, stderr := capture(t, func() { err = execute([]string{"lists", "rename", "--ndjson", "x"}) })
+	if err == nil {
+		t.Fatal("execute() succeeded, want an error")
+	}
+	if stderr != "" {
+		t.Errorf("stderr = %q, want nothing", stderr)
+	}
+
+	var events []streamEvent
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		var e streamEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("stdout line is not JSON: %v\n%s", err, line)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 2 || events[0].Event != eventStart || events[1].Event != eventError {
+		t.Fatalf("events = %s, want a start and an error event", stdout)
+	}
+	if events[0].Command != "bring lists rename" || !reflect.DeepEqual(events[0].Args, []string{"x"}) ||
+		!strings.Contains(events[1].Message, "accepts 2 arg(s)") {
+		t.Errorf("events = %s, want the start and argument error of lists rename", stdout)
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// outputSchemas maps commands, by their path without "bring", to the type
+// of their JSON output.
+var outputSchemas = map[string]interface{}{
+	"activity":        activityOutput{},
+	"add":             itemsChangeOutput{},
+	"complete":        itemsChangeOutput{},
+	"config notify":   notifySettingsOutput{},
+	"config set-list": listChangeOutput{},
+	"error":           errorOutput{},
+	"event":           streamEvent{},
+	"flag":            itemsChangeOutput{},
+	"history":         historyOutput{},
+	"list":            listItemsOutput{},
+	"lists":           listsOutput{},
+	"lists create":    listChangeOutput{},
+	"lists delete":    listChangeOutput{},
+	"lists rename":    listChangeOutput{},
+	"lists theme":     listChangeOutput{},
+	"login":           loginOutput{},
+	"logout":          envelope{},
+	"members":         membersOutput{},
+	"members invite":  memberChangeOutput{},
+	"members remove":  memberChangeOutput{},
+	"notify":          notifyOutput{},
+	"photo get":       itemPhotoOutput{},
+	"photo set":       itemPhotoOutput{},
+	"purge-recently":  itemsChangeOutput{},
+	"recur add":       recurOutput{},
+	"recur list":      recurListOutput{},
+	"recur remove":    recurRemoveOutput{},
+	"recur run":       recurRunOutput{},
+	"remove":          itemsChangeOutput{},
+	"restore-item":    itemsChangeOutput{},
+	"stats":           statsOutput{},
+	"suggest":         suggestOutput{},
+	"template apply":  templateApplyOutput{},
+	"template create": templateOutput{},
+	"template delete": templateDeleteOutput{},
+	"template show":   templatesOutput{},
+	"undo":            undoOutput{},
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [command]",
+	Short: "Print the JSON Schema of a command's output",
+	Long: `Print the JSON Schema of the JSON output of a command.
+
+Every JSON output has a schemaVersion, which changes only when fields are
+removed or changed, and a success field. Errors are printed to stderr as
+described by "bring schema error". With --ndjson, every line is an event
+described by "bring schema event". Without a command, lists the commands
+with a schema.
+
+template show prints a single template ("template" instead of
+"templates") when given a name.
+
+Examples:
+  bring schema
+  bring schema list
+  bring schema lists create`,
+	RunE: runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		names := make([]string, 0, len(outputSchemas))
+		for name := range outputSchemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if isStructured() {
+			return printOutput(map[string]interface{}{
+				"schemaVersion": schemaVersion,
+				"commands":      names,
+			})
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "COMMAND\tOUTPUT")
+		for _, name := range names {
+			fmt.Fprintf(w, "%s\t%s\n", name, reflect.TypeOf(outputSchemas[name]).Name())
+		}
+		w.Flush()
+		return nil
+	}
+
+	name := strings.Join(args, " ")
+	if found, rest, err := rootCmd.Find(args); err == nil && len(rest) == 0 && found != rootCmd {
+		name = commandName(found)
+	}
+	output, ok := outputSchemas[name]
+	if !ok {
+		return fmt.Errorf("no schema for command: %s\nRun 'bring schema' to see the commands with a schema", name)
+	}
+
+	schema := jsonSchema(reflect.TypeOf(output))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = fmt.Sprintf("bring %s output (schema version %d)", name, schemaVersion)
+
+	// The schema is JSON even without --json
+	if !isStructured() {
+		outputMode = outputJSON
+	}
+	return printOutput(schema)
+}
+
+// commandName returns the path of a command without "bring".
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// jsonSchema returns the JSON Schema of values of type t, as encoded by
+// encoding/json.
+func jsonSchema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		required := []string{}
+		schemaFields(t, properties, &required)
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": true, // fields may be added without a new schemaVersion
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// schemaFields adds the JSON fields of a struct to properties, flattening
+// embedded structs like encoding/json. Fields without omitempty are
+// required.
+func schemaFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			schemaFields(field.Type, properties, required)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := jsonSchema(field.Type)
+		if name == "schemaVersion" {
+			schema["const"] = schemaVersion
+		}
+		properties[name] = schema
+
+		omitEmpty := false
+		for _, option := range tag[1:] {
+			omitEmpty = omitEmpty || option == "omitempty"
+		}
+		if !omitEmpty {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestOutputSchemas(t *testing.T) {
+	// Commands without JSON output of their own
+	noOutput := map[string]bool{"shop": true, "schema": true}
+	// Commands cobra adds on execute, e.g. by other tests
+	generated := map[string]bool{"help": true, "completion": true}
+
+	commands := make(map[string]bool)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, sub := range cmd.Commands() {
+			if cmd == rootCmd && generated[sub.Name()] {
+				continue
+			}
+			name := commandName(sub)
+			commands[name] = true
+			if _, ok := outputSchemas[name]; sub.Runnable() && !noOutput[name] && !ok {
+				t.Errorf("Expected a schema for %q", name)
+			}
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+
+	for name, output := range outputSchemas {
+		if !commands[name] && name != "error" && name != "event" {
+			t.Errorf("Schema for unknown command %q", name)
+		}
+		if schema := jsonSchema(reflect.TypeOf(output)); schema["type"] != "object" {
+			t.Errorf("Expected the schema of %q to be an object, got %v", name, schema["type"])
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// shopFlushDelay is how long changes are collected before they are sent
+// to the server in a single batch.
+const shopFlushDelay = 2 * time.Second
+
+var (
+	shopList    string
+	shopRefresh time.Duration
+)
+
+var shopCmd = &cobra.Command{
+	Use:     "shop",
+	Aliases: []string{"tui"},
+	Short:   "Interactive shopping mode",
+	Long: `Open a full-screen view of the purchase list for ticking items off
+while shopping.
+
+Keys:
+  up/down, k/j  move the selection
+  space         complete the selected item
+  a             add an item ("item:spec" sets a specification)
+  e             edit the selected item's specification
+  d             remove the selected item
+  u             undo the last change
+  r             refresh the list now
+  q             quit
+
+Changes are sent in batches and the list is refreshed periodically.
+If no list is specified, uses the default list.
+
+Examples:
+  bring shop
+  bring shop --list Office --refresh 10s`,
+	Args: cobra.NoArgs,
+	RunE: runShop,
+}
+
+func init() {
+	shopCmd.Flags().StringVarP(&shopList, "list", "l", "", "target list UUID or name")
+	shopCmd.Flags().DurationVar(&shopRefresh, "refresh", 30*time.Second, "interval between list refreshes")
+	rootCmd.AddCommand(shopCmd)
+}
+
+func runShop(cmd *cobra.Command, args []string) error {
+	if isNDJSON() {
+		return fmt.Errorf("shopping mode is interactive and has no event stream")
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("shopping mode requires an interactive terminal")
+	}
+	if shopRefresh <= 0 {
+		return fmt.Errorf("invalid refresh interval: %s", shopRefresh)
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, shopList)
+	if err != nil {
+		return err
+	}
+
+	ui := &shopUI{
+		client:   client,
+		listUUID: listUUID,
+		listName: listUUID,
+		out:      bufio.NewWriter(os.Stdout),
+	}
+	if lists, err := client.GetLists(); err == nil {
+		if list := findList(lists.Lists, listUUID); list != nil {
+			ui.listName = list.Name
+		}
+	}
+	if err := ui.refresh(); err != nil {
+		return err
+	}
+
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("entering raw mode: %w", err)
+	}
+	// Switch to the alternate screen and hide the cursor
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		fmt.Print("\x1b[?25h\x1b[?1049l")
+		_ = term.Restore(int(os.Stdin.Fd()), oldState)
+	}()
+
+	return ui.run()
+}
+
+// shopUI is the state of the interactive shopping mode.
+type shopUI struct {
+	client   *bringapi.Client
+	listUUID string
+	listName string
+	out      *bufio.Writer
+
+	items   *bringapi.ListItemsResponse
+	cursor  int
+	offset  int
+	pending []bringapi.ItemChange
+	undo    []bringapi.ItemChange
+	status  string
+
+	// Line input state while a prompt is open
+	prompt   string
+	input    []rune
+	onSubmit func(string)
+
+	flushTimer *time.Timer
+}
+
+// run is the event loop. It returns once the user quits and all pending
+// changes have been sent.
+func (ui *shopUI) run() error {
+	keys := make(chan []byte)
+	go func() {
+		buf := make([]byte, 32)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			key := make([]byte, n)
+			copy(key, buf[:n])
+			keys <- key
+		}
+	}()
+
+	ticker := time.NewTicker(shopRefresh)
+	defer ticker.Stop()
+
+	for {
+		ui.render()
+
+		var flushC <-chan time.Time
+		if ui.flushTimer != nil {
+			flushC = ui.flushTimer.C
+		}
+
+		select {
+		case key, ok := <-keys:
+			if !ok || ui.handleKey(key) {
+				return ui.flush()
+			}
+		case <-ticker.C:
+			if err := ui.refresh(); err != nil {
+				ui.status = err.Error()
+			}
+		case <-flushC:
+			if err := ui.flush(); err != nil {
+				ui.status = err.Error()
+			}
+		}
+	}
+}
+
+// handleKey processes a key press and reports whether the user quit.
+func (ui *shopUI) handleKey(key []byte) bool {
+	if ui.onSubmit != nil {
+		ui.handlePromptKey(key)
+		return false
+	}
+
+	ui.status = ""
+	purchase := ui.items.Items.Purchase
+
+	switch string(key) {
+	case "q", "\x03":
+		return true
+	case "k", "\x1b[A", "\x1bOA":
+		if ui.cursor > 0 {
+			ui.cursor--
+		}
+	case "j", "\x1b[B", "\x1bOB":
+		if ui.cursor < len(purchase)-1 {
+			ui.cursor++
+		}
+	case " ":
+		if item := ui.selected(); item != nil {
+			itemID := item.ItemID
+			ui.apply(bringapi.ItemChange{ItemID: itemID, Spec: item.Specification, Operation: bringapi.OperationComplete}, true)
+			ui.status = "Completed " + itemID
+		}
+	case "d":
+		if item := ui.selected(); item != nil {
+			itemID := item.ItemID
+			ui.apply(bringapi.ItemChange{ItemID: itemID, Operation: bringapi.OperationRemove}, true)
+			ui.status = "Removed " + itemID
+		}
+	case "a":
+		ui.openPrompt("Add item: ", "", func(value string) {
+			itemID, spec := parseItemArg(value)
+			if itemID == "" {
+				return
+			}
+			ui.apply(bringapi.ItemChange{ItemID: itemID, Spec: spec, Operation: bringapi.OperationAdd}, true)
+			ui.status = "Added " + itemID
+		})
+	case "e":
+		if item := ui.selected(); item != nil {
+			itemID := item.ItemID
+			ui.openPrompt("Specification for "+itemID+": ", item.Specification, func(value string) {
+				ui.apply(bringapi.ItemChange{ItemID: itemID, Spec: value, Operation: bringapi.OperationAdd}, true)
+				ui.status = "Updated " + itemID
+			})
+		}
+	case "u":
+		if len(ui.undo) == 0 {
+			ui.status = "Nothing to undo"
+			break
+		}
+		change := ui.undo[len(ui.undo)-1]
+		ui.undo = ui.undo[:len(ui.undo)-1]
+		ui.apply(change, false)
+		ui.status = "Undid last change to " + change.ItemID
+	case "r":
+		if err := ui.refresh(); err != nil {
+			ui.status = err.Error()
+		} else {
+			ui.status = "Refreshed"
+		}
+	}
+
+	return false
+}
+
+// handlePromptKey edits the input line of an open prompt.
+func (ui *shopUI) handlePromptKey(key []byte) {
+	switch string(key) {
+	case "\r", "\n":
+		submit := ui.onSubmit
+		value := strings.TrimSpace(string(ui.input))
+		ui.closePrompt()
+		submit(value)
+	case "\x1b", "\x03":
+		ui.closePrompt()
+	case "\x7f", "\b":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		// Ignore escape sequences such as arrow keys
+		if key[0] == 0x1b {
+			return
+		}
+		for _, r := range string(key) {
+			if r >= ' ' {
+				ui.input = append(ui.input, r)
+			}
+		}
+	}
+}
+
+// openPrompt starts reading a line of input.
+func (ui *shopUI) openPrompt(prompt, initial string, onSubmit func(string)) {
+	ui.prompt = prompt
+	ui.input = []rune(initial)
+	ui.onSubmit = onSubmit
+}
+
+// closePrompt discards the open prompt.
+func (ui *shopUI) closePrompt() {
+	ui.prompt = ""
+	ui.input = nil
+	ui.onSubmit = nil
+}
+
+// selected returns the item under the cursor, or nil if the list is empty.
+func (ui *shopUI) selected() *bringapi.ListItem {
+	purchase := ui.items.Items.Purchase
+	if ui.cursor < 0 || ui.cursor >= len(purchase) {
+		return nil
+	}
+	return &purchase[ui.cursor]
+}
+
+// apply updates the local list and queues the change for the next batch.
+// If recordUndo is set, the change that restores the item's previous state
+// is pushed onto the undo stack.
+func (ui *shopUI) apply(change bringapi.ItemChange, recordUndo bool) {
+	prior, location := ui.items.FindItem(change.ItemID)
+	if change.UUID == "" {
+		if prior != nil {
+			change.UUID = prior.UUID
+		} else {
+			change.UUID = uuid.New().String()
+		}
+	}
+
+	if recordUndo {
+		before := bringapi.ListItem{ItemID: change.ItemID, UUID: change.UUID}
+		if prior != nil {
+			before = *prior
+		}
+		ui.undo = append(ui.undo, bringapi.RestoreChange(before, location))
+	}
+
+	ui.items.Apply(change)
+	ui.pending = append(ui.pending, change)
+	ui.clampCursor()
+
+	if ui.flushTimer != nil {
+		ui.flushTimer.Stop()
+	}
+	ui.flushTimer = time.NewTimer(shopFlushDelay)
+}
+
+// flush sends all queued changes in a single batch.
+func (ui *shopUI) flush() error {
+	if ui.flushTimer != nil {
+		ui.flushTimer.Stop()
+		ui.flushTimer = nil
+	}
+	if len(ui.pending) == 0 {
+		return nil
+	}
+
+	if err := updateItems(ui.client, ui.listUUID, "shop", nil, ui.pending); err != nil {
+		return fmt.Errorf("updating items: %w", err)
+	}
+	ui.pending = nil
+	return nil
+}
+
+// refresh sends queued changes and reloads the list from the server.
+func (ui *shopUI) refresh() error {
+	if err := ui.flush(); err != nil {
+		return err
+	}
+
+	items, err := ui.client.GetListItems(ui.listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching list items: %w", err)
+	}
+	ui.items = items
+	ui.clampCursor()
+	return nil
+}
+
+// clampCursor keeps the cursor within the purchase list.
+func (ui *shopUI) clampCursor() {
+	if n := len(ui.items.Items.Purchase); ui.cursor >= n {
+		ui.cursor = n - 1
+	}
+	if ui.cursor < 0 {
+		ui.cursor = 0
+	}
+}
+
+// render redraws the whole screen.
+func (ui *shopUI) render() {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		width, height = 80, 24
+	}
+
+	w := ui.out
+	w.WriteString("\x1b[H\x1b[2J")
+
+	purchase := ui.items.Items.Purchase
+	header := fmt.Sprintf("%s - %d to buy, %d recently completed", ui.listName, len(purchase), len(ui.items.Items.Recently))
+	if len(ui.pending) > 0 {
+		header += fmt.Sprintf(" (%d unsaved)", len(ui.pending))
+	}
+	fmt.Fprintf(w, "%s\r\n", style(roleHeader, truncate(header, width)))
+	fmt.Fprintf(w, "%s\r\n", strings.Repeat("-", width))
+
+	// Header, two separators, status and help take five lines
+	rows := height - 5
+	if rows < 1 {
+		rows = 1
+	}
+	if ui.cursor < ui.offset {
+		ui.offset = ui.cursor
+	}
+	if ui.cursor >= ui.offset+rows {
+		ui.offset = ui.cursor - rows + 1
+	}
+
+	if len(purchase) == 0 {
+		w.WriteString("Nothing to buy\r\n")
+		rows--
+	}
+	for i := ui.offset; i < len(purchase) && i < ui.offset+rows; i++ {
+		item := purchase[i]
+		text := item.ItemID
+		if item.Specification != "" {
+			text += "  (" + item.Specification + ")"
+		}
+		line := truncate("  "+text, width)
+		if i == ui.cursor {
+			// Without colors, the cursor is marked instead
+			if styled := style(roleCursor, line); styled != line {
+				line = styled
+			} else {
+				line = truncate("> "+text, width)
+			}
+		}
+		fmt.Fprintf(w, "%s\r\n", line)
+		rows--
+	}
+	for ; rows > 0; rows-- {
+		w.WriteString("\r\n")
+	}
+
+	fmt.Fprintf(w, "%s\r\n", strings.Repeat("-", width))
+	if ui.onSubmit != nil {
+		cursor := style(roleCursor, " ")
+		if cursor == " " {
+			cursor = "_"
+		}
+		fmt.Fprintf(w, "%s%s%s\r\n", ui.prompt, string(ui.input), cursor)
+		w.WriteString(truncate("enter confirm  esc cancel", width))
+	} else {
+		fmt.Fprintf(w, "%s\r\n", truncate(ui.status, width))
+		w.WriteString(truncate("space complete  a add  e edit  d remove  u undo  r refresh  q quit", width))
+	}
+
+	w.Flush()
+}
+
+// truncate shortens s to at most width runes.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width])
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/internal/journal"
+	"github.com/julianfbeck/bring-cli/internal/stats"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+// Purchase sources for stats and suggestions.
+const (
+	sourceAll    = "all"
+	sourceLocal  = "local"
+	sourceServer = "server"
+)
+
+var (
+	statsPeriod string
+	statsSource string
+	statsTop    int
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [list-uuid-or-name]",
+	Short: "Show purchase statistics",
+	Long: `Show how often items are bought, the busiest shopping days and who
+does the shopping.
+
+Completions are taken from the local history journal and Bring's activity
+timeline. Use --source to restrict to one of them.
+
+If no list is provided, uses the default list.
+
+Examples:
+  bring stats
+  bring stats Office --period 30d
+  bring stats --source local --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().StringVarP(&statsPeriod, "period", "p", "90d", "time period to analyze (e.g. 30d, 12w)")
+	statsCmd.Flags().StringVar(&statsSource, "source", sourceAll, "where to read completions from: all, local, server")
+	statsCmd.Flags().IntVarP(&statsTop, "top", "n", 20, "number of items to show (0 for all)")
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	period, err := parseDuration(statsPeriod)
+	if err != nil {
+		return err
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	argList := ""
+	if len(args) > 0 {
+		argList = args[0]
+	}
+	listUUID, err := resolveListUUID(client, argList)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().Add(-period)
+	purchases, err := collectPurchases(client, listUUID, statsSource, since)
+	if err != nil {
+		return err
+	}
+
+	report := stats.Compute(purchases)
+	if statsTop > 0 && len(report.Items) > statsTop {
+		report.Items = report.Items[:statsTop]
+	}
+
+	if isStructured() {
+		return printOutput(statsOutput{
+			envelope: newEnvelope(true),
+			List:     listUUID,
+			Since:    since,
+			Source:   statsSource,
+			Stats:    newOutputStats(report),
+		})
+	}
+
+	if report.Total == 0 {
+		fmt.Printf("No purchases in the last %s\n", statsPeriod)
+		return nil
+	}
+
+	fmt.Printf("Purchases in the last %s: %d\n\n", statsPeriod, report.Total)
+
+	fmt.Println("Items:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  ITEM\tCOUNT\tAVG INTERVAL\tLAST BOUGHT")
+	for _, item := range report.Items {
+		interval := "-"
+		if item.AverageIntervalDays > 0 {
+			interval = fmt.Sprintf("%.1f days", item.AverageIntervalDays)
+		}
+		fmt.Fprintf(w, "  %s\t%d\t%s\t%s\n", item.ItemID, item.Count, interval, item.LastPurchase.Local().Format("2006-01-02"))
+	}
+	w.Flush()
+
+	fmt.Println("\nBusiest Days:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  DAY\tCOUNT")
+	for _, day := range report.Days {
+		fmt.Fprintf(w, "  %s\t%d\n", day.Day, day.Count)
+	}
+	w.Flush()
+
+	if len(report.Contributors) > 0 {
+		fmt.Println("\nTop Contributors:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  USER\tCOUNT")
+		for _, user := range report.Contributors {
+			fmt.Fprintf(w, "  %s\t%d\n", user.User, user.Count)
+		}
+		w.Flush()
+	}
+
+	return nil
+}
+
+// collectPurchases returns the completions of items on a list since the
+// given time, read from the local journal and/or the server activity feed.
+func collectPurchases(client *bringapi.Client, listUUID, source string, since time.Time) ([]stats.Purchase, error) {
+	if source != sourceAll && source != sourceLocal && source != sourceServer {
+		return nil, fmt.Errorf("invalid source: %s (use: all, local, server)", source)
+	}
+
+	var server, local []stats.Purchase
+
+	if source != sourceLocal {
+		events, err := fetchActivity(client, listUUID, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			if event.Type != bringapi.ActivityItemsRemoved {
+				continue
+			}
+			user := event.UserName
+			if user == "" {
+				user = event.PublicUserUUID
+			}
+			for _, item := range event.Items {
+				server = append(server, stats.Purchase{ItemID: item.ItemID, Time: event.Time, User: user})
+			}
+		}
+	}
+
+	if source != sourceServer {
+		entries, err := journal.Load()
+		if err != nil {
+			return nil, err
+		}
+		records := journal.Records(entries, journal.Filter{ListUUID: listUUID, Since: since, ExcludeUndone: true})
+		for _, r := range records {
+			if r.Operation != "complete" || r.Result != journal.ResultOK {
+				continue
+			}
+			local = append(local, stats.Purchase{ItemID: r.ItemID, Time: r.Time, User: r.Profile})
+		}
+	}
+
+	// Prefer server events, which carry member names
+	return stats.Merge(server, local), nil
+}
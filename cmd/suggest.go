@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/internal/stats"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var (
+	suggestPeriod        string
+	suggestSource        string
+	suggestMinConfidence float64
+	suggestAdd           bool
+	suggestTop           int
+)
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest [list-uuid-or-name]",
+	Short: "Suggest items you usually buy by now",
+	Long: `Predict which items are due based on how often they were bought before.
+
+Each item's typical interval between purchases is learned from past
+completions. Items that are close to or past that interval, and not
+already on the purchase list, are suggested with a confidence score.
+
+If no list is provided, uses the default list.
+
+Examples:
+  bring suggest
+  bring suggest Office --period 180d
+  bring suggest --add --top 3`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSuggest,
+}
+
+func init() {
+	suggestCmd.Flags().StringVarP(&suggestPeriod, "period", "p", "180d", "history to learn from (e.g. 90d, 26w)")
+	suggestCmd.Flags().StringVar(&suggestSource, "source", sourceAll, "where to read completions from: all, local, server")
+	suggestCmd.Flags().Float64Var(&suggestMinConfidence, "min-confidence", 0.2, "only suggest items with at least this confidence (0-1)")
+	suggestCmd.Flags().BoolVarP(&suggestAdd, "add", "a", false, "add the top suggestions to the list")
+	suggestCmd.Flags().IntVarP(&suggestTop, "top", "n", 5, "number of suggestions to show or add (0 for all)")
+	rootCmd.AddCommand(suggestCmd)
+}
+
+func runSuggest(cmd *cobra.Command, args []string) error {
+	period, err := parseDuration(suggestPeriod)
+	if err != nil {
+		return err
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	argList := ""
+	if len(args) > 0 {
+		argList = args[0]
+	}
+	listUUID, err := resolveListUUID(client, argList)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	purchases, err := collectPurchases(client, listUUID, suggestSource, now.Add(-period))
+	if err != nil {
+		return err
+	}
+
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching list items: %w", err)
+	}
+
+	var suggestions []stats.Suggestion
+	for _, s := range stats.Suggest(purchases, now) {
+		if s.Confidence < suggestMinConfidence {
+			continue
+		}
+		if _, location := items.FindItem(s.ItemID); location == bringapi.LocationPurchase {
+			continue
+		}
+		suggestions = append(suggestions, s)
+	}
+	if suggestTop > 0 && len(suggestions) > suggestTop {
+		suggestions = suggestions[:suggestTop]
+	}
+
+	var added []string
+	if suggestAdd && len(suggestions) > 0 {
+		var changes []bringapi.ItemChange
+		for _, s := range suggestions {
+			changes = append(changes, bringapi.ItemChange{ItemID: s.ItemID, Operation: bringapi.OperationAdd})
+			added = append(added, s.ItemID)
+		}
+		if err := updateItems(client, listUUID, "suggest", items, changes); err != nil {
+			return fmt.Errorf("adding items: %w", err)
+		}
+	}
+
+	if isStructured() {
+		return printOutput(suggestOutput{
+			envelope:    newEnvelope(true),
+			List:        listUUID,
+			Suggestions: newOutputSuggestions(suggestions),
+			Added:       nonNil(added),
+		})
+	}
+
+	if len(suggestions) == 0 {
+		fmt.Println("No suggestions - nothing seems due")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ITEM\tEVERY\tLAST BOUGHT\tCONFIDENCE")
+	for _, s := range suggestions {
+		fmt.Fprintf(w, "%s\t%.1f days\t%.0f days ago\t%.0f%%\n", s.ItemID, s.AverageIntervalDays, s.DaysSinceLast, s.Confidence*100)
+	}
+	w.Flush()
+
+	if len(added) > 0 {
+		printSuccess("\nAdded %d items to list: %s", len(added), strings.Join(added, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/julianfbeck/bring-cli/internal/config"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var (
+	templateFromList   bool
+	templateSourceList string
+	templateForce      bool
+	templateApplyList  string
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage reusable item templates",
+	Long: `Manage named bundles of items that can be added to any list at once.
+
+Templates are stored in ~/.config/bring-cli/templates.yaml.`,
+}
+
+var templateCreateCmd = &cobra.Command{
+	Use:   "create <name> [item[:spec]]...",
+	Short: "Create a template",
+	Long: `Create a named template from the given items.
+
+Append ":spec" to an item to store a specification with it.
+Use --from-list to create the template from the items currently
+on a list's purchase list.
+
+Examples:
+  bring template create breakfast Brot Butter "Eier:10"
+  bring template create party --from-list --list abc123
+  bring template create breakfast Brot Kaffee --force`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTemplateCreate,
+}
+
+var templateShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show templates",
+	Long: `Show all templates, or the items of a single template.
+
+Examples:
+  bring template show
+  bring template show breakfast`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplateShow,
+}
+
+var templateApplyCmd = &cobra.Command{
+	Use:   "apply <name>",
+	Short: "Add a template's items to a list",
+	Long: `Add all items of a template to a shopping list.
+
+Items already on the list's purchase list are skipped.
+If no list is specified, uses the default list.
+
+Examples:
+  bring template apply breakfast
+  bring template apply breakfast --list Office`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateApply,
+}
+
+var templateDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a template",
+	Long: `Delete a stored template.
+
+Example:
+  bring template delete breakfast`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateDelete,
+}
+
+func init() {
+	templateCreateCmd.Flags().BoolVar(&templateFromList, "from-list", false, "create the template from the list's current items")
+	templateCreateCmd.Flags().StringVarP(&templateSourceList, "list", "l", "", "source list UUID or name for --from-list")
+	templateCreateCmd.Flags().BoolVarP(&templateForce, "force", "f", false, "overwrite an existing template")
+	templateApplyCmd.Flags().StringVarP(&templateApplyList, "list", "l", "", "target list UUID or name")
+
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateCreateCmd)
+	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateApplyCmd)
+	templateCmd.AddCommand(templateDeleteCmd)
+}
+
+func runTemplateCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	existing, err := config.GetTemplate(name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && !templateForce {
+		return fmt.Errorf("template already exists: %s (use --force to overwrite)", name)
+	}
+
+	tmpl := &config.Template{Name: name}
+	for _, arg := range args[1:] {
+		itemID, spec := parseItemArg(arg)
+		if itemID == "" {
+			return fmt.Errorf("invalid item: %q", arg)
+		}
+		tmpl.Items = append(tmpl.Items, config.TemplateItem{ItemID: itemID, Spec: spec})
+	}
+
+	if templateFromList {
+		client, err := getAuthenticatedClient()
+		if err != nil {
+			return err
+		}
+
+		listUUID, err := resolveListUUID(client, templateSourceList)
+		if err != nil {
+			return err
+		}
+
+		items, err := client.GetListItems(listUUID)
+		if err != nil {
+			return fmt.Errorf("fetching list items: %w", err)
+		}
+
+		for _, item := range items.Items.Purchase {
+			tmpl.Items = append(tmpl.Items, config.TemplateItem{ItemID: item.ItemID, Spec: item.Specification})
+		}
+	}
+
+	if len(tmpl.Items) == 0 {
+		return fmt.Errorf("template has no items. Pass items as arguments or use --from-list")
+	}
+
+	if err := config.SaveTemplate(tmpl); err != nil {
+		return fmt.Errorf("saving template: %w", err)
+	}
+
+	if isStructured() {
+		return printOutput(templateOutput{envelope: newEnvelope(true), Template: newOutputTemplate(tmpl)})
+	}
+
+	printSuccess("Created template %s with %d items", name, len(tmpl.Items))
+	return nil
+}
+
+func runTemplateShow(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 {
+		tmpl, err := config.GetTemplate(args[0])
+		if err != nil {
+			return err
+		}
+		if tmpl == nil {
+			return fmt.Errorf("template not found: %s", args[0])
+		}
+
+		if isStructured() {
+			return printOutput(templateOutput{envelope: newEnvelope(true), Template: newOutputTemplate(tmpl)})
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ITEM\tSPECIFICATION")
+		for _, item := range tmpl.Items {
+			fmt.Fprintf(w, "%s\t%s\n", item.ItemID, item.Spec)
+		}
+		w.Flush()
+		return nil
+	}
+
+	templates, err := config.ListTemplates()
+	if err != nil {
+		return err
+	}
+
+	if isStructured() {
+		return printOutput(templatesOutput{envelope: newEnvelope(true), Templates: newOutputTemplates(templates)})
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("No templates found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tITEMS")
+	for _, tmpl := range templates {
+		var names []string
+		for _, item := range tmpl.Items {
+			names = append(names, item.ItemID)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", tmpl.Name, strings.Join(names, ", "))
+	}
+	w.Flush()
+
+	return nil
+}
+
+func runTemplateApply(cmd *cobra.Command, args []string) error {
+	tmpl, err := config.GetTemplate(args[0])
+	if err != nil {
+		return err
+	}
+	if tmpl == nil {
+		return fmt.Errorf("template not found: %s", args[0])
+	}
+
+	client, err := getAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	listUUID, err := resolveListUUID(client, templateApplyList)
+	if err != nil {
+		return err
+	}
+
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		return fmt.Errorf("fetching list items: %w", err)
+	}
+
+	onList := make(map[string]bool)
+	for _, item := range items.Items.Purchase {
+		onList[strings.ToLower(item.ItemID)] = true
+	}
+
+	var changes []bringapi.ItemChange
+	var added, skipped []string
+	for _, item := range tmpl.Items {
+		if onList[strings.ToLower(item.ItemID)] {
+			skipped = append(skipped, item.ItemID)
+			continue
+		}
+		changes = append(changes, bringapi.ItemChange{
+			ItemID:    item.ItemID,
+			Spec:      item.Spec,
+			Operation: bringapi.OperationAdd,
+		})
+		added = append(added, item.ItemID)
+	}
+
+	if len(changes) > 0 {
+		if err := updateItems(client, listUUID, "template apply", items, changes); err != nil {
+			return fmt.Errorf("adding items: %w", err)
+		}
+	}
+
+	if isStructured() {
+		return printOutput(templateApplyOutput{
+			envelope: newEnvelope(true),
+			Template: tmpl.Name,
+			List:     listUUID,
+			Added:    nonNil(added),
+			Skipped:  nonNil(skipped),
+		})
+	}
+
+	printSuccess("Added %d items from template %s", len(added), tmpl.Name)
+	if len(skipped) > 0 {
+		printSuccess("Skipped %d items already on the list: %s", len(skipped), strings.Join(skipped, ", "))
+	}
+
+	return nil
+}
+
+func runTemplateDelete(cmd *cobra.Command, args []string) error {
+	found, err := config.DeleteTemplate(args[0])
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("template not found: %s", args[0])
+	}
+
+	if isStructured() {
+		return printOutput(templateDeleteOutput{envelope: newEnvelope(true), Template: args[0]})
+	}
+
+	printSuccess("Deleted template %s", args[0])
+	return nil
+}
+
+// parseItemArg splits an "item:spec" argument into item name and specification.
+func parseItemArg(arg string) (string, string) {
+	itemID, spec, _ := strings.Cut(arg, ":")
+	return strings.TrimSpace(itemID), strings.TrimSpace(spec)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/julianfbeck/bring-cli/internal/journal"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoSteps  int
+	undoDryRun bool
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last change(s) made with bring",
+	Long: `Undo the most recent changes made by add, complete, remove and other
+commands that modify a list.
+
+Every change is recorded in ~/.config/bring-cli/journal.jsonl together
+with the previous state of the touched items, which undo restores.
+Undoing twice goes further back; it does not redo.
+
+Examples:
+  bring undo
+  bring undo --steps 3
+  bring undo --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+func init() {
+	undoCmd.Flags().IntVarP(&undoSteps, "steps", "n", 1, "number of changes to undo")
+	undoCmd.Flags().BoolVar(&undoDryRun, "dry-run", false, "show what would be undone without changing anything")
+	rootCmd.AddCommand(undoCmd)
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	if undoSteps < 1 {
+		return fmt.Errorf("--steps must be at least 1")
+	}
+
+	entries, err := journal.Load()
+	if err != nil {
+		return err
+	}
+
+	undoable := journal.Undoable(entries, undoSteps)
+	if len(undoable) == 0 {
+		return fmt.Errorf("nothing to undo")
+	}
+
+	var client *bringapi.Client
+	if !undoDryRun {
+		client, err = getAuthenticatedClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	undone := []undoneEntry{}
+	for _, entry := range undoable {
+		changes := entry.Inverse()
+
+		if !undoDryRun {
+			undo := journal.NewEntry("undo", entry.ListUUID, changes)
+			undo.Reverts = entry.ID
+			if err := recordUpdate(client, undo, nil); err != nil {
+				return fmt.Errorf("undoing %s: %w", entry.Command, err)
+			}
+		}
+
+		var items []string
+		for _, change := range changes {
+			items = append(items, change.ItemID)
+		}
+		undone = append(undone, undoneEntry{
+			ID:      entry.ID,
+			Command: entry.Command,
+			List:    entry.ListUUID,
+			Time:    entry.Time,
+			Changes: newOutputChanges(changes),
+		})
+
+		verb := "Undid"
+		if undoDryRun {
+			verb = "Would undo"
+		}
+		printSuccess("%s %s of %s (%s)", verb, entry.Command, strings.Join(items, ", "), entry.Time.Format("2006-01-02 15:04"))
+	}
+
+	if isStructured() {
+		return printOutput(undoOutput{envelope: newEnvelope(true), DryRun: undoDryRun, Undone: undone})
+	}
+
+	return nil
+}
+
+// updateItems sends a batch of changes to a list and records it in the
+// journal for history and undo. current is the list state before the
+// changes; if nil, it is fetched first.
+func updateItems(client *bringapi.Client, listUUID, command string, current *bringapi.ListItemsResponse, changes []bringapi.ItemChange) error {
+	return recordUpdate(client, journal.NewEntry(command, listUUID, changes), current)
+}
+
+// recordUpdate sends the changes of a journal entry and appends the entry
+// to the journal, whether or not the server accepted them. The prior
+// state of the touched items is taken from current, or fetched if current
+// is nil.
+func recordUpdate(client *bringapi.Client, entry *journal.Entry, current *bringapi.ListItemsResponse) error {
+	if current == nil {
+		var err error
+		current, err = client.GetListItems(entry.ListUUID)
+		if err != nil {
+			return fmt.Errorf("fetching list items: %w", err)
+		}
+	}
+
+	entry.CapturePrior(current)
+	if creds := client.GetCredentials(); creds != nil {
+		entry.Profile = creds.Email
+	}
+
+	updateErr := client.UpdateItems(entry.ListUUID, entry.Changes)
+	invalidateCompletionCache(itemsCacheFile(entry.ListUUID))
+	entry.Result = journal.ResultOK
+	if updateErr != nil {
+		entry.Result = journal.ResultFailed
+		entry.Error = updateErr.Error()
+	} else if isNDJSON() {
+		emitItemEvents(entry.ListUUID, entry.Changes)
+	}
+
+	if err := journal.Append(entry); err != nil {
+		printWarning("could not record change in journal: %v", err)
+	}
+
+	return updateErr
+}
@@ -4,6 +4,7 @@ go 1.21
 
 require (
 	github.com/google/uuid v1.4.0
+	github.com/itchyny/gojq v0.12.16
 	github.com/spf13/cobra v1.8.0
 	golang.org/x/term v0.16.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -11,8 +12,9 @@ require (
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/itchyny/timefmt-go v0.1.6 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/sys v0.16.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
 )
@@ -5,7 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/julianfbeck/bring-cli/internal/api"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,11 +16,14 @@ const (
 
 // Config holds the CLI configuration.
 type Config struct {
-	Credentials *api.Credentials `yaml:"credentials,omitempty"`
+	Credentials *bringapi.Credentials `yaml:"credentials,omitempty"`
+	Notify      *NotifySettings       `yaml:"notify,omitempty"`
+	Colors      map[string]string     `yaml:"colors,omitempty"`
 }
 
-// GetConfigPath returns the path to the config file.
-func GetConfigPath() (string, error) {
+// GetConfigDir returns the directory holding the config file and other
+// local state such as templates.
+func GetConfigDir() (string, error) {
 	configHome := os.Getenv("XDG_CONFIG_HOME")
 	if configHome == "" {
 		home, err := os.UserHomeDir()
@@ -29,7 +32,21 @@ func GetConfigPath() (string, error) {
 		}
 		configHome = filepath.Join(home, ".config")
 	}
-	return filepath.Join(configHome, configDir, configFile), nil
+	return filepath.Join(configHome, configDir), nil
+}
+
+// GetConfigPath returns the path to the config file.
+func GetConfigPath() (string, error) {
+	return getFilePath(configFile)
+}
+
+// getFilePath returns the path to a file in the config directory.
+func getFilePath(name string) (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
 }
 
 // Load loads the configuration from disk.
@@ -39,17 +56,9 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return &Config{}, nil
-		}
-		return nil, fmt.Errorf("reading config file: %w", err)
-	}
-
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("parsing config file: %w", err)
+	if err := readYAML(path, &cfg); err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
 	}
 
 	return &cfg, nil
@@ -62,26 +71,50 @@ func Save(cfg *Config) error {
 		return err
 	}
 
+	if err := writeYAML(path, cfg); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+
+	return nil
+}
+
+// readYAML decodes the YAML file at path into v.
+// A missing file is not an error and leaves v untouched.
+func readYAML(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
+
+// writeYAML encodes v as YAML and writes it to path, creating the
+// config directory if needed.
+func writeYAML(path string, v interface{}) error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
 	}
 
-	data, err := yaml.Marshal(cfg)
+	data, err := yaml.Marshal(v)
 	if err != nil {
-		return fmt.Errorf("marshaling config: %w", err)
+		return fmt.Errorf("marshaling %s: %w", filepath.Base(path), err)
 	}
 
-	if err := os.WriteFile(path, data, 0600); err != nil {
-		return fmt.Errorf("writing config file: %w", err)
-	}
-
-	return nil
+	return os.WriteFile(path, data, 0600)
 }
 
 // SaveCredentials saves credentials to the config.
-func SaveCredentials(creds *api.Credentials) error {
+func SaveCredentials(creds *bringapi.Credentials) error {
 	cfg, err := Load()
 	if err != nil {
 		cfg = &Config{}
@@ -91,7 +124,7 @@ func SaveCredentials(creds *api.Credentials) error {
 }
 
 // GetCredentials returns stored credentials.
-func GetCredentials() (*api.Credentials, error) {
+func GetCredentials() (*bringapi.Credentials, error) {
 	cfg, err := Load()
 	if err != nil {
 		return nil, err
@@ -99,6 +132,19 @@ func GetCredentials() (*api.Credentials, error) {
 	return cfg.Credentials, nil
 }
 
+// TokenStore stores the credentials of a bringapi.Client in the config.
+type TokenStore struct{}
+
+// Load returns stored credentials.
+func (TokenStore) Load() (*bringapi.Credentials, error) {
+	return GetCredentials()
+}
+
+// Save saves credentials to the config.
+func (TokenStore) Save(creds *bringapi.Credentials) error {
+	return SaveCredentials(creds)
+}
+
 // ClearCredentials removes stored credentials.
 func ClearCredentials() error {
 	cfg, err := Load()
@@ -116,7 +162,7 @@ func SetDefaultList(listUUID string) error {
 		cfg = &Config{}
 	}
 	if cfg.Credentials == nil {
-		cfg.Credentials = &api.Credentials{}
+		cfg.Credentials = &bringapi.Credentials{}
 	}
 	cfg.Credentials.DefaultList = listUUID
 	return Save(cfg)
@@ -130,3 +176,12 @@ func GetDefaultList() string {
 	}
 	return cfg.Credentials.DefaultList
 }
+
+// GetColors returns the configured color palette overrides, by role.
+func GetColors() map[string]string {
+	cfg, err := Load()
+	if err != nil {
+		return nil
+	}
+	return cfg.Colors
+}
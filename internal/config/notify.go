@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	notifyStateFile = "notify-state.yaml"
+
+	// DefaultNotifyDebounce is how long automatic notifications of the
+	// same kind are suppressed after one was sent for a list.
+	DefaultNotifyDebounce = 2 * time.Minute
+)
+
+// NotifySettings controls the notifications sent automatically after
+// changing a list.
+type NotifySettings struct {
+	// Changes sends a changed-list notification after add, remove and complete.
+	Changes bool `yaml:"changes" json:"changes"`
+	// ShoppingDone sends a shopping-done notification after complete --all.
+	ShoppingDone bool `yaml:"shopping_done" json:"shoppingDone"`
+	// Debounce is a duration such as "2m"; empty means DefaultNotifyDebounce.
+	Debounce string `yaml:"debounce,omitempty" json:"debounce,omitempty"`
+}
+
+// DebounceDuration returns the parsed debounce window.
+func (s NotifySettings) DebounceDuration() (time.Duration, error) {
+	if s.Debounce == "" {
+		return DefaultNotifyDebounce, nil
+	}
+	d, err := time.ParseDuration(s.Debounce)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid notify debounce %q: use a duration like 30s or 2m", s.Debounce)
+	}
+	return d, nil
+}
+
+// GetNotifySettings returns the stored notification settings, or the
+// defaults (nothing sent automatically) if there are none.
+func GetNotifySettings() NotifySettings {
+	cfg, err := Load()
+	if err != nil || cfg.Notify == nil {
+		return NotifySettings{}
+	}
+	return *cfg.Notify
+}
+
+// SetNotifySettings stores the notification settings.
+func SetNotifySettings(settings NotifySettings) error {
+	cfg, err := Load()
+	if err != nil {
+		cfg = &Config{}
+	}
+	cfg.Notify = &settings
+	return Save(cfg)
+}
+
+// notifyState records when notifications were last sent, by list UUID
+// and notification type.
+type notifyState struct {
+	Sent map[string]map[string]time.Time `yaml:"sent"`
+}
+
+// NotificationDebounced reports whether a notification of the given type
+// was sent for a list within window. The state is shared between
+// processes, so a script changing a list many times in a row only
+// notifies once.
+func NotificationDebounced(listUUID, notificationType string, window time.Duration) (bool, error) {
+	state, _, err := loadNotifyState()
+	if err != nil {
+		return false, err
+	}
+	last, ok := state.Sent[listUUID][notificationType]
+	return ok && time.Since(last) < window, nil
+}
+
+// RecordNotification records that a notification of the given type was
+// sent for a list now.
+func RecordNotification(listUUID, notificationType string) error {
+	state, path, err := loadNotifyState()
+	if err != nil {
+		return err
+	}
+
+	// Forget lists that haven't been notified in a while
+	now := time.Now()
+	for list, types := range state.Sent {
+		for t, last := range types {
+			if now.Sub(last) > 24*time.Hour {
+				delete(types, t)
+			}
+		}
+		if len(types) == 0 {
+			delete(state.Sent, list)
+		}
+	}
+
+	if state.Sent == nil {
+		state.Sent = make(map[string]map[string]time.Time)
+	}
+	if state.Sent[listUUID] == nil {
+		state.Sent[listUUID] = make(map[string]time.Time)
+	}
+	state.Sent[listUUID][notificationType] = now
+
+	if err := writeYAML(path, state); err != nil {
+		return fmt.Errorf("writing notify state file: %w", err)
+	}
+	return nil
+}
+
+// loadNotifyState reads the notify state file and returns its path.
+func loadNotifyState() (*notifyState, string, error) {
+	path, err := getFilePath(notifyStateFile)
+	if err != nil {
+		return nil, "", err
+	}
+
+	state := &notifyState{}
+	if err := readYAML(path, state); err != nil {
+		return nil, "", fmt.Errorf("reading notify state file: %w", err)
+	}
+	return state, path, nil
+}
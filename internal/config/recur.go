@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const recurFile = "recurring.yaml"
+
+// RecurringRule re-adds an item to a list at a fixed interval. LastRun
+// is zero until the rule first ran, and is then left out of the file.
+type RecurringRule struct {
+	ItemID   string    `yaml:"item" json:"itemId"`
+	Spec     string    `yaml:"spec,omitempty" json:"spec,omitempty"`
+	ListUUID string    `yaml:"list" json:"list"`
+	Every    string    `yaml:"every" json:"every"`
+	LastRun  time.Time `yaml:"last_run,omitempty" json:"lastRun"`
+}
+
+// Matches reports whether the rule is for the given item and list.
+func (r *RecurringRule) Matches(itemID, listUUID string) bool {
+	return strings.EqualFold(r.ItemID, itemID) && r.ListUUID == listUUID
+}
+
+// recurStore is the on-disk layout of the recurring rules file.
+type recurStore struct {
+	Rules []*RecurringRule `yaml:"rules"`
+}
+
+// LoadRecurringRules returns all stored recurring rules.
+func LoadRecurringRules() ([]*RecurringRule, error) {
+	path, err := getFilePath(recurFile)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &recurStore{}
+	if err := readYAML(path, store); err != nil {
+		return nil, fmt.Errorf("reading recurring rules file: %w", err)
+	}
+
+	return store.Rules, nil
+}
+
+// SaveRecurringRules replaces all stored recurring rules.
+func SaveRecurringRules(rules []*RecurringRule) error {
+	path, err := getFilePath(recurFile)
+	if err != nil {
+		return err
+	}
+
+	if err := writeYAML(path, &recurStore{Rules: rules}); err != nil {
+		return fmt.Errorf("writing recurring rules file: %w", err)
+	}
+
+	return nil
+}
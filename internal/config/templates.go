@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+const templatesFile = "templates.yaml"
+
+// TemplateItem is a single item stored in a template.
+type TemplateItem struct {
+	ItemID string `yaml:"item" json:"itemId"`
+	Spec   string `yaml:"spec,omitempty" json:"spec,omitempty"`
+}
+
+// Template is a named, reusable bundle of items.
+type Template struct {
+	Name  string         `yaml:"-" json:"name"`
+	Items []TemplateItem `yaml:"items" json:"items"`
+}
+
+// templateStore is the on-disk layout of the templates file.
+type templateStore struct {
+	Templates map[string]*Template `yaml:"templates"`
+}
+
+// loadTemplateStore reads the templates file.
+func loadTemplateStore() (*templateStore, error) {
+	path, err := getFilePath(templatesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &templateStore{}
+	if err := readYAML(path, store); err != nil {
+		return nil, fmt.Errorf("reading templates file: %w", err)
+	}
+	if store.Templates == nil {
+		store.Templates = make(map[string]*Template)
+	}
+	for name, tmpl := range store.Templates {
+		// Entries left empty in the file have no items to add
+		if tmpl == nil {
+			delete(store.Templates, name)
+			continue
+		}
+		tmpl.Name = name
+	}
+
+	return store, nil
+}
+
+// saveTemplateStore writes the templates file.
+func saveTemplateStore(store *templateStore) error {
+	path, err := getFilePath(templatesFile)
+	if err != nil {
+		return err
+	}
+
+	if err := writeYAML(path, store); err != nil {
+		return fmt.Errorf("writing templates file: %w", err)
+	}
+
+	return nil
+}
+
+// ListTemplates returns all stored templates sorted by name.
+func ListTemplates() ([]*Template, error) {
+	store, err := loadTemplateStore()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*Template, 0, len(store.Templates))
+	for _, tmpl := range store.Templates {
+		templates = append(templates, tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
+
+// GetTemplate returns the named template, or nil if it doesn't exist.
+func GetTemplate(name string) (*Template, error) {
+	store, err := loadTemplateStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Templates[name], nil
+}
+
+// SaveTemplate creates or replaces a template.
+func SaveTemplate(tmpl *Template) error {
+	store, err := loadTemplateStore()
+	if err != nil {
+		return err
+	}
+	store.Templates[tmpl.Name] = tmpl
+	return saveTemplateStore(store)
+}
+
+// DeleteTemplate removes the named template.
+// It reports whether the template existed.
+func DeleteTemplate(name string) (bool, error) {
+	store, err := loadTemplateStore()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := store.Templates[name]; !ok {
+		return false, nil
+	}
+	delete(store.Templates, name)
+	return true, saveTemplateStore(store)
+}
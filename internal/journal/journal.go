@@ -0,0 +1,299 @@
+// Package journal records list mutations made by the CLI in an
+// append-only local log, so they can be audited and undone later.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julianfbeck/bring-cli/internal/config"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+const journalFile = "journal.jsonl"
+
+// Results of a recorded batch.
+const (
+	ResultOK     = "ok"
+	ResultFailed = "failed"
+)
+
+// Entry is a single recorded batch of changes to a list.
+type Entry struct {
+	ID       string                `json:"id"`
+	Time     time.Time             `json:"time"`
+	Profile  string                `json:"profile,omitempty"`
+	Command  string                `json:"command"`
+	ListUUID string                `json:"list"`
+	Changes  []bringapi.ItemChange `json:"changes"`
+	Prior    []PriorState          `json:"prior"`
+	Reverts  string                `json:"reverts,omitempty"`
+	Result   string                `json:"result,omitempty"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// Failed reports whether the batch was rejected by the server.
+func (e *Entry) Failed() bool {
+	return e.Result == ResultFailed
+}
+
+// PriorState is the state of a touched item before the changes were made.
+// An empty location means the item was not on the list.
+type PriorState struct {
+	Item     bringapi.ListItem `json:"item"`
+	Location string            `json:"location,omitempty"`
+}
+
+// NewEntry returns an entry for changes about to be made to a list.
+func NewEntry(command, listUUID string, changes []bringapi.ItemChange) *Entry {
+	return &Entry{
+		ID:       uuid.New().String(),
+		Time:     time.Now(),
+		Command:  command,
+		ListUUID: listUUID,
+		Changes:  changes,
+	}
+}
+
+// CapturePrior records the state in current of every item touched by
+// the entry's changes.
+func (e *Entry) CapturePrior(current *bringapi.ListItemsResponse) {
+	e.Prior = nil
+	seen := make(map[string]bool)
+	for _, change := range e.Changes {
+		if seen[change.ItemID] {
+			continue
+		}
+		seen[change.ItemID] = true
+
+		state := PriorState{Item: bringapi.ListItem{ItemID: change.ItemID}}
+		if item, location := current.FindItem(change.ItemID); item != nil {
+			state.Item = *item
+			state.Location = location
+		}
+		e.Prior = append(e.Prior, state)
+	}
+}
+
+// Inverse returns the changes that restore every touched item to its
+// prior state.
+func (e *Entry) Inverse() []bringapi.ItemChange {
+	var changes []bringapi.ItemChange
+	for _, state := range e.Prior {
+		item := state.Item
+		// Items that were not on the list are removed by the UUID they were added with
+		if item.UUID == "" {
+			for _, change := range e.Changes {
+				if change.ItemID == item.ItemID {
+					item.UUID = change.UUID
+				}
+			}
+		}
+		changes = append(changes, bringapi.RestoreChange(item, state.Location))
+
+		// Restore the flags of items that stay on the list
+		if state.Location != "" && e.updatesAttributes(item.ItemID) {
+			changes = append(changes, bringapi.ConditionsChange(item.ItemID, item.UUID, item.Conditions()))
+		}
+	}
+	return changes
+}
+
+// updatesAttributes reports whether the entry changes attributes of itemID.
+func (e *Entry) updatesAttributes(itemID string) bool {
+	for _, change := range e.Changes {
+		if change.Operation == bringapi.OperationAttributeUpdate && strings.EqualFold(change.ItemID, itemID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Path returns the path to the journal file.
+func Path() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, journalFile), nil
+}
+
+// Append adds an entry to the end of the journal.
+func Append(entry *Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("opening journal: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling journal entry: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+
+	return nil
+}
+
+// Load returns all journal entries, oldest first.
+func Load() ([]*Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("parsing journal line %d: %w", line, err)
+		}
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading journal: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Undoable returns up to n entries that can still be undone, newest first.
+// Entries that failed, are themselves undos, or have already been undone
+// are skipped.
+func Undoable(entries []*Entry, n int) []*Entry {
+	reverted := revertedIDs(entries)
+
+	var undoable []*Entry
+	for i := len(entries) - 1; i >= 0 && len(undoable) < n; i-- {
+		entry := entries[i]
+		if entry.Failed() || entry.Reverts != "" || reverted[entry.ID] {
+			continue
+		}
+		undoable = append(undoable, entry)
+	}
+	return undoable
+}
+
+// revertedIDs returns the IDs of all entries that have been undone.
+func revertedIDs(entries []*Entry) map[string]bool {
+	reverted := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Reverts != "" {
+			reverted[entry.Reverts] = true
+		}
+	}
+	return reverted
+}
+
+// Record is a single item change from the journal, flattened for display.
+type Record struct {
+	Time      time.Time `json:"time"`
+	Profile   string    `json:"profile,omitempty"`
+	ListUUID  string    `json:"list"`
+	Command   string    `json:"command"`
+	Operation string    `json:"operation"`
+	ItemID    string    `json:"itemId"`
+	Spec      string    `json:"spec,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Filter selects records from the journal. Zero fields match everything.
+type Filter struct {
+	ListUUID string
+	Since    time.Time
+	ItemID   string
+
+	// ExcludeUndone drops undo entries and the entries they reverted,
+	// leaving only changes that are still in effect.
+	ExcludeUndone bool
+}
+
+// Records flattens entries into one record per item change, oldest first,
+// keeping only those matching the filter.
+func Records(entries []*Entry, filter Filter) []Record {
+	reverted := revertedIDs(entries)
+
+	var records []Record
+	for _, entry := range entries {
+		if filter.ExcludeUndone && (entry.Reverts != "" || reverted[entry.ID]) {
+			continue
+		}
+		if filter.ListUUID != "" && entry.ListUUID != filter.ListUUID {
+			continue
+		}
+		if !filter.Since.IsZero() && entry.Time.Before(filter.Since) {
+			continue
+		}
+
+		result := entry.Result
+		if result == "" {
+			result = ResultOK
+		}
+
+		for _, change := range entry.Changes {
+			if filter.ItemID != "" && !strings.EqualFold(change.ItemID, filter.ItemID) {
+				continue
+			}
+			records = append(records, Record{
+				Time:      entry.Time,
+				Profile:   entry.Profile,
+				ListUUID:  entry.ListUUID,
+				Command:   entry.Command,
+				Operation: OperationName(change.Operation),
+				ItemID:    change.ItemID,
+				Spec:      change.Spec,
+				Result:    result,
+				Error:     entry.Error,
+			})
+		}
+	}
+	return records
+}
+
+// OperationName returns a readable name for an item operation.
+func OperationName(operation string) string {
+	switch operation {
+	case bringapi.OperationAdd:
+		return "add"
+	case bringapi.OperationComplete:
+		return "complete"
+	case bringapi.OperationRemove:
+		return "remove"
+	case bringapi.OperationAttributeUpdate:
+		return "flag"
+	default:
+		return strings.ToLower(operation)
+	}
+}
@@ -0,0 +1,130 @@
+package journal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/internal/journal"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+func TestInverse(t *testing.T) {
+	current := &bringapi.ListItemsResponse{
+		Items: bringapi.Items{
+			Purchase: []bringapi.ListItem{{UUID: "u1", ItemID: "Milch", Specification: "1.5%"}},
+			Recently: []bringapi.ListItem{{UUID: "u2", ItemID: "Brot", Specification: "Vollkorn"}},
+		},
+	}
+
+	entry := journal.NewEntry("test", "list", []bringapi.ItemChange{
+		{ItemID: "Milch", Operation: bringapi.OperationRemove},
+		{ItemID: "Brot", Operation: bringapi.OperationAdd},
+		{ItemID: "Eier", UUID: "u3", Operation: bringapi.OperationAdd},
+	})
+	entry.CapturePrior(current)
+
+	inverse := entry.Inverse()
+	if len(inverse) != 3 {
+		t.Fatalf("Expected 3 inverse changes, got %d", len(inverse))
+	}
+
+	expected := []bringapi.ItemChange{
+		{ItemID: "Milch", Spec: "1.5%", UUID: "u1", Operation: bringapi.OperationAdd},
+		{ItemID: "Brot", Spec: "Vollkorn", UUID: "u2", Operation: bringapi.OperationComplete},
+		{ItemID: "Eier", UUID: "u3", Operation: bringapi.OperationRemove},
+	}
+	for i, want := range expected {
+		if inverse[i] != want {
+			t.Errorf("Inverse change %d: expected %+v, got %+v", i, want, inverse[i])
+		}
+	}
+}
+
+func TestInverseRestoresConditions(t *testing.T) {
+	current := &bringapi.ListItemsResponse{
+		Items: bringapi.Items{
+			Purchase: []bringapi.ListItem{{
+				UUID:   "u1",
+				ItemID: "Milch",
+				Attributes: []bringapi.Attribute{
+					bringapi.ConditionsAttribute(bringapi.PurchaseConditions{Discounted: true}),
+				},
+			}},
+		},
+	}
+
+	entry := journal.NewEntry("flag", "list", []bringapi.ItemChange{
+		bringapi.ConditionsChange("Milch", "u1", bringapi.PurchaseConditions{Urgent: true}),
+	})
+	entry.CapturePrior(current)
+
+	inverse := entry.Inverse()
+	if len(inverse) != 2 {
+		t.Fatalf("Expected 2 inverse changes, got %d", len(inverse))
+	}
+
+	restore := inverse[1]
+	if restore.Operation != bringapi.OperationAttributeUpdate || restore.UUID != "u1" {
+		t.Fatalf("Expected an attribute update of u1, got %+v", restore)
+	}
+	restored := bringapi.ListItem{Attributes: []bringapi.Attribute{*restore.Attribute}}
+	if want := (bringapi.PurchaseConditions{Discounted: true}); restored.Conditions() != want {
+		t.Errorf("Expected conditions %+v to be restored, got %+v", want, restored.Conditions())
+	}
+}
+
+func TestUndoable(t *testing.T) {
+	first := journal.NewEntry("add", "list", nil)
+	second := journal.NewEntry("remove", "list", nil)
+	undo := journal.NewEntry("undo", "list", nil)
+	undo.Reverts = second.ID
+
+	entries := []*journal.Entry{first, second, undo}
+
+	undoable := journal.Undoable(entries, 5)
+	if len(undoable) != 1 || undoable[0] != first {
+		t.Fatalf("Expected only the first entry to be undoable, got %d entries", len(undoable))
+	}
+
+	if got := journal.Undoable(entries[:2], 1); len(got) != 1 || got[0] != second {
+		t.Error("Expected the newest entry to be undone first")
+	}
+}
+
+func TestRecords(t *testing.T) {
+	old := journal.NewEntry("add", "list-a", []bringapi.ItemChange{
+		{ItemID: "Milch", Spec: "1L", Operation: bringapi.OperationAdd},
+	})
+	old.Time = time.Now().Add(-48 * time.Hour)
+
+	recent := journal.NewEntry("complete", "list-b", []bringapi.ItemChange{
+		{ItemID: "Milch", Operation: bringapi.OperationComplete},
+		{ItemID: "Brot", Operation: bringapi.OperationComplete},
+	})
+	recent.Result = journal.ResultFailed
+	recent.Error = "boom"
+
+	entries := []*journal.Entry{old, recent}
+
+	if got := journal.Records(entries, journal.Filter{}); len(got) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(got))
+	}
+
+	got := journal.Records(entries, journal.Filter{ItemID: "milch"})
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 records for Milch, got %d", len(got))
+	}
+	if got[0].Operation != "add" || got[0].Result != journal.ResultOK {
+		t.Errorf("Unexpected first record: %+v", got[0])
+	}
+	if got[1].Operation != "complete" || got[1].Result != journal.ResultFailed {
+		t.Errorf("Unexpected second record: %+v", got[1])
+	}
+
+	if got := journal.Records(entries, journal.Filter{Since: time.Now().Add(-time.Hour)}); len(got) != 2 {
+		t.Errorf("Expected 2 records since an hour ago, got %d", len(got))
+	}
+	if got := journal.Records(entries, journal.Filter{ListUUID: "list-a"}); len(got) != 1 {
+		t.Errorf("Expected 1 record for list-a, got %d", len(got))
+	}
+}
@@ -0,0 +1,185 @@
+// Package match resolves item names typed by a user to items on a list,
+// tolerating case, accents, localized names and small typos.
+package match
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+// NotFoundError is returned when no item matches a query.
+type NotFoundError struct {
+	Query string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("item not found: %s", e.Query)
+}
+
+// Is lets errors.Is match a NotFoundError against bringapi.ErrItemNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == bringapi.ErrItemNotFound
+}
+
+// AmbiguousError is returned when a query matches several items equally well.
+type AmbiguousError struct {
+	Query      string
+	Candidates []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%q matches several items: %s (be more specific or use --exact)", e.Query, strings.Join(e.Candidates, ", "))
+}
+
+// Resolve returns the item in items that best matches query.
+//
+// Matches are tried in order of strictness, and the first kind that
+// matches anything wins:
+//  1. the exact ItemID
+//  2. the ItemID or localized name, ignoring case and accents
+//  3. a prefix of the ItemID or localized name, ignoring case and accents
+//  4. the closest ItemID or localized name within a small edit distance
+//
+// translations maps ItemIDs to localized names and may be nil.
+func Resolve(query string, items []bringapi.ListItem, translations map[string]string) (*bringapi.ListItem, error) {
+	for i, item := range items {
+		if item.ItemID == query {
+			return &items[i], nil
+		}
+	}
+
+	q := Fold(query)
+	if q == "" {
+		return nil, &NotFoundError{Query: query}
+	}
+
+	names := func(item bringapi.ListItem) []string {
+		folded := []string{Fold(item.ItemID)}
+		if localized, ok := translations[item.ItemID]; ok && localized != "" {
+			folded = append(folded, Fold(localized))
+		}
+		return folded
+	}
+
+	matchers := []func(name string) bool{
+		func(name string) bool { return name == q },
+		func(name string) bool { return strings.HasPrefix(name, q) },
+	}
+	for _, matches := range matchers {
+		var found []int
+		for i, item := range items {
+			for _, name := range names(item) {
+				if matches(name) {
+					found = append(found, i)
+					break
+				}
+			}
+		}
+		if item, err := pick(query, items, found); item != nil || err != nil {
+			return item, err
+		}
+	}
+
+	// Fall back to the closest names within the allowed distance
+	limit := maxDistance(q)
+	best := limit + 1
+	var found []int
+	for i, item := range items {
+		dist := limit + 1
+		for _, name := range names(item) {
+			if d := Distance(q, name); d < dist {
+				dist = d
+			}
+		}
+		switch {
+		case dist < best:
+			best = dist
+			found = []int{i}
+		case dist == best && dist <= limit:
+			found = append(found, i)
+		}
+	}
+	if item, err := pick(query, items, found); item != nil || err != nil {
+		return item, err
+	}
+
+	return nil, &NotFoundError{Query: query}
+}
+
+// pick returns the single found item, an AmbiguousError if there are
+// several, or nil if there are none.
+func pick(query string, items []bringapi.ListItem, found []int) (*bringapi.ListItem, error) {
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &items[found[0]], nil
+	}
+
+	var candidates []string
+	for _, i := range found {
+		candidates = append(candidates, items[i].ItemID)
+	}
+	return nil, &AmbiguousError{Query: query, Candidates: candidates}
+}
+
+// maxDistance returns the number of typos tolerated for a query.
+func maxDistance(q string) int {
+	switch n := len([]rune(q)); {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// accents maps accented letters to their unaccented form.
+var accents = map[rune]string{
+	'ä': "a", 'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'å': "a",
+	'ö': "o", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ø': "o",
+	'ü': "u", 'ù': "u", 'ú': "u", 'û': "u",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'ç': "c", 'ñ': "n", 'ß': "ss",
+}
+
+// Fold lowercases s, strips accents and collapses whitespace, so that
+// e.g. "Käse " and "kase" compare equal.
+func Fold(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.Join(strings.Fields(s), " ")) {
+		if plain, ok := accents[r]; ok {
+			b.WriteString(plain)
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Distance returns the Levenshtein edit distance between a and b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,87 @@
+package match_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianfbeck/bring-cli/internal/match"
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+func TestResolve(t *testing.T) {
+	items := []bringapi.ListItem{
+		{ItemID: "Milch"},
+		{ItemID: "Käse"},
+		{ItemID: "Orangensaft"},
+		{ItemID: "Apfel"},
+		{ItemID: "Apfelmus"},
+	}
+	translations := map[string]string{
+		"Milch":       "Milk",
+		"Orangensaft": "Orange Juice",
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"Milch", "Milch"},
+		{"milch", "Milch"},
+		{"Milk", "Milch"},
+		{"kase", "Käse"},
+		{"KÄSE", "Käse"},
+		{"orange juice", "Orangensaft"},
+		{"Orangen", "Orangensaft"},
+		{"Apfel", "Apfel"},
+		{"Milck", "Milch"},
+		{"Orangnsaft", "Orangensaft"},
+	}
+
+	for _, tt := range tests {
+		item, err := match.Resolve(tt.query, items, translations)
+		if err != nil {
+			t.Errorf("Resolve(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if item.ItemID != tt.want {
+			t.Errorf("Resolve(%q) = %s, expected %s", tt.query, item.ItemID, tt.want)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	items := []bringapi.ListItem{{ItemID: "Apfelmus"}, {ItemID: "Apfelsaft"}, {ItemID: "Brot"}}
+
+	_, err := match.Resolve("apfel", items, nil)
+	var ambiguous *match.AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Expected an ambiguous match, got %v", err)
+	}
+	if len(ambiguous.Candidates) != 2 {
+		t.Errorf("Expected 2 candidates, got %v", ambiguous.Candidates)
+	}
+
+	_, err = match.Resolve("Bier", items, nil)
+	var notFound *match.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Expected item not found, got %v", err)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"milch", "milch", 0},
+		{"milch", "mlich", 2},
+		{"kaese", "kase", 1},
+		{"brot", "", 4},
+	}
+	for _, tt := range tests {
+		if got := match.Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package stats aggregates item purchases into shopping statistics.
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// mergeWindow is how close in time two purchases of the same item from
+// different sources must be to count as one.
+const mergeWindow = 10 * time.Minute
+
+// Purchase is a single completion of an item.
+type Purchase struct {
+	ItemID string
+	Time   time.Time
+	User   string
+}
+
+// ItemStats summarizes the purchases of a single item.
+type ItemStats struct {
+	ItemID              string    `json:"itemId"`
+	Count               int       `json:"count"`
+	FirstPurchase       time.Time `json:"firstPurchase"`
+	LastPurchase        time.Time `json:"lastPurchase"`
+	AverageIntervalDays float64   `json:"averageIntervalDays,omitempty"`
+}
+
+// AverageInterval returns the average time between purchases, or zero
+// if the item was bought only once.
+func (s ItemStats) AverageInterval() time.Duration {
+	if s.Count < 2 {
+		return 0
+	}
+	return s.LastPurchase.Sub(s.FirstPurchase) / time.Duration(s.Count-1)
+}
+
+// DayStats counts purchases made on a day of the week.
+type DayStats struct {
+	Day   string `json:"day"`
+	Count int    `json:"count"`
+}
+
+// UserStats counts purchases made by a list member.
+type UserStats struct {
+	User  string `json:"user"`
+	Count int    `json:"count"`
+}
+
+// Report is the aggregated statistics of a set of purchases.
+type Report struct {
+	Total        int         `json:"total"`
+	Items        []ItemStats `json:"items"`
+	Days         []DayStats  `json:"days"`
+	Contributors []UserStats `json:"contributors"`
+}
+
+// Merge combines purchases from several sources, oldest first. A purchase
+// of an item within a few minutes of one already seen is treated as the
+// same purchase reported twice; the earlier source wins.
+func Merge(sources ...[]Purchase) []Purchase {
+	var merged []Purchase
+	for _, source := range sources {
+		for _, p := range source {
+			duplicate := false
+			for _, seen := range merged {
+				if Key(seen.ItemID) == Key(p.ItemID) && absDuration(seen.Time.Sub(p.Time)) <= mergeWindow {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				merged = append(merged, p)
+			}
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged
+}
+
+// Compute aggregates purchases into a report. Items are keyed by ItemID
+// case-insensitively and sorted by purchase count, days and contributors
+// by number of purchases.
+func Compute(purchases []Purchase) *Report {
+	report := &Report{
+		Total:        len(purchases),
+		Items:        []ItemStats{},
+		Days:         []DayStats{},
+		Contributors: []UserStats{},
+	}
+
+	items := make(map[string]*ItemStats)
+	var itemOrder []string
+	days := make(map[time.Weekday]int)
+	users := make(map[string]int)
+
+	for _, p := range purchases {
+		key := Key(p.ItemID)
+		s, ok := items[key]
+		if !ok {
+			s = &ItemStats{ItemID: p.ItemID, FirstPurchase: p.Time, LastPurchase: p.Time}
+			items[key] = s
+			itemOrder = append(itemOrder, key)
+		}
+		s.Count++
+		if p.Time.Before(s.FirstPurchase) {
+			s.FirstPurchase = p.Time
+		}
+		if p.Time.After(s.LastPurchase) {
+			s.LastPurchase = p.Time
+		}
+
+		days[p.Time.Local().Weekday()]++
+		if p.User != "" {
+			users[p.User]++
+		}
+	}
+
+	for _, key := range itemOrder {
+		s := items[key]
+		if interval := s.AverageInterval(); interval > 0 {
+			s.AverageIntervalDays = interval.Hours() / 24
+		}
+		report.Items = append(report.Items, *s)
+	}
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].Count > report.Items[j].Count
+	})
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if days[day] > 0 {
+			report.Days = append(report.Days, DayStats{Day: day.String(), Count: days[day]})
+		}
+	}
+	sort.SliceStable(report.Days, func(i, j int) bool {
+		return report.Days[i].Count > report.Days[j].Count
+	})
+
+	for user, count := range users {
+		report.Contributors = append(report.Contributors, UserStats{User: user, Count: count})
+	}
+	sort.Slice(report.Contributors, func(i, j int) bool {
+		if report.Contributors[i].Count != report.Contributors[j].Count {
+			return report.Contributors[i].Count > report.Contributors[j].Count
+		}
+		return report.Contributors[i].User < report.Contributors[j].User
+	})
+
+	return report
+}
+
+// Key returns the aggregation key for an ItemID.
+func Key(itemID string) string {
+	return strings.ToLower(strings.TrimSpace(itemID))
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,98 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/internal/stats"
+)
+
+func TestCompute(t *testing.T) {
+	start := time.Date(2024, 3, 4, 10, 0, 0, 0, time.Local) // a Monday
+	purchases := []stats.Purchase{
+		{ItemID: "Milch", Time: start, User: "anna"},
+		{ItemID: "milch", Time: start.Add(4 * 24 * time.Hour), User: "ben"},
+		{ItemID: "Milch", Time: start.Add(7 * 24 * time.Hour), User: "anna"},
+		{ItemID: "Brot", Time: start.Add(7 * 24 * time.Hour), User: "anna"},
+	}
+
+	report := stats.Compute(purchases)
+
+	if report.Total != 4 {
+		t.Errorf("Expected 4 purchases, got %d", report.Total)
+	}
+	if len(report.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(report.Items))
+	}
+
+	milch := report.Items[0]
+	if milch.ItemID != "Milch" || milch.Count != 3 {
+		t.Errorf("Expected Milch bought 3 times first, got %s bought %d times", milch.ItemID, milch.Count)
+	}
+	if milch.AverageIntervalDays != 3.5 {
+		t.Errorf("Expected average interval of 3.5 days, got %.2f", milch.AverageIntervalDays)
+	}
+	if report.Items[1].AverageIntervalDays != 0 {
+		t.Error("Expected no average interval for an item bought once")
+	}
+
+	if report.Days[0].Day != "Monday" || report.Days[0].Count != 3 {
+		t.Errorf("Expected Monday to be the busiest day, got %+v", report.Days[0])
+	}
+	if report.Contributors[0].User != "anna" || report.Contributors[0].Count != 3 {
+		t.Errorf("Expected anna to be the top contributor, got %+v", report.Contributors[0])
+	}
+}
+
+func TestMerge(t *testing.T) {
+	now := time.Now()
+	server := []stats.Purchase{{ItemID: "Milch", Time: now, User: "Anna"}}
+	local := []stats.Purchase{
+		{ItemID: "Milch", Time: now.Add(time.Minute), User: "anna@example.com"},
+		{ItemID: "Milch", Time: now.Add(-48 * time.Hour), User: "anna@example.com"},
+	}
+
+	merged := stats.Merge(server, local)
+	if len(merged) != 2 {
+		t.Fatalf("Expected 2 purchases after merging, got %d", len(merged))
+	}
+	if merged[1].User != "Anna" {
+		t.Errorf("Expected the server purchase to win, got %s", merged[1].User)
+	}
+	if !merged[0].Time.Before(merged[1].Time) {
+		t.Error("Expected purchases sorted oldest first")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+
+	var purchases []stats.Purchase
+	// Coffee every 7 days, last bought 8 days ago: due
+	for i := 0; i < 5; i++ {
+		purchases = append(purchases, stats.Purchase{ItemID: "Kaffee", Time: now.Add(-time.Duration(8+7*i) * day)})
+	}
+	// Milk every 3 days, last bought yesterday: not due
+	for i := 0; i < 5; i++ {
+		purchases = append(purchases, stats.Purchase{ItemID: "Milch", Time: now.Add(-time.Duration(1+3*i) * day)})
+	}
+	// Bread bought once: no interval
+	purchases = append(purchases, stats.Purchase{ItemID: "Brot", Time: now.Add(-30 * day)})
+
+	suggestions := stats.Suggest(purchases, now)
+	if len(suggestions) != 1 {
+		t.Fatalf("Expected 1 suggestion, got %d: %+v", len(suggestions), suggestions)
+	}
+
+	coffee := suggestions[0]
+	if coffee.ItemID != "Kaffee" {
+		t.Errorf("Expected Kaffee to be suggested, got %s", coffee.ItemID)
+	}
+	if coffee.AverageIntervalDays < 6.9 || coffee.AverageIntervalDays > 7.1 {
+		t.Errorf("Expected an interval of 7 days, got %.2f", coffee.AverageIntervalDays)
+	}
+	if coffee.Confidence < 0.5 || coffee.Confidence > 1 {
+		t.Errorf("Expected a high confidence for a regular item, got %.2f", coffee.Confidence)
+	}
+}
@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// dueRatio is the fraction of an item's typical interval after which it
+// is considered due again.
+const dueRatio = 0.9
+
+// Suggestion is an item predicted to be due for repurchase.
+type Suggestion struct {
+	ItemID              string    `json:"itemId"`
+	LastPurchase        time.Time `json:"lastPurchase"`
+	Purchases           int       `json:"purchases"`
+	AverageIntervalDays float64   `json:"averageIntervalDays"`
+	DaysSinceLast       float64   `json:"daysSinceLast"`
+	Confidence          float64   `json:"confidence"`
+}
+
+// Suggest predicts which items are due at the given time, based on each
+// item's typical interval between purchases. Items need at least two
+// purchases to have an interval. Confidence grows with the number of
+// purchases and the regularity of the intervals. Suggestions are ranked
+// by confidence weighted by how overdue the item is.
+func Suggest(purchases []Purchase, now time.Time) []Suggestion {
+	times := make(map[string][]time.Time)
+	names := make(map[string]string)
+	for _, p := range purchases {
+		key := Key(p.ItemID)
+		if _, ok := names[key]; !ok {
+			names[key] = p.ItemID
+		}
+		times[key] = append(times[key], p.Time)
+	}
+
+	suggestions := []Suggestion{}
+	score := make(map[string]float64)
+	for key, ts := range times {
+		if len(ts) < 2 {
+			continue
+		}
+		sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+
+		var intervals []float64
+		for i := 1; i < len(ts); i++ {
+			intervals = append(intervals, ts[i].Sub(ts[i-1]).Hours()/24)
+		}
+		mean, stddev := meanStddev(intervals)
+		if mean <= 0 {
+			continue
+		}
+
+		last := ts[len(ts)-1]
+		since := now.Sub(last).Hours() / 24
+		ratio := since / mean
+		if ratio < dueRatio {
+			continue
+		}
+
+		// More history and steadier intervals make a prediction more reliable
+		history := math.Min(1, float64(len(intervals))/5)
+		regularity := 1 - math.Min(1, stddev/mean)
+		confidence := history * (0.5 + 0.5*regularity)
+
+		suggestions = append(suggestions, Suggestion{
+			ItemID:              names[key],
+			LastPurchase:        last,
+			Purchases:           len(ts),
+			AverageIntervalDays: mean,
+			DaysSinceLast:       since,
+			Confidence:          confidence,
+		})
+		score[names[key]] = confidence * math.Min(ratio, 2)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		si, sj := score[suggestions[i].ItemID], score[suggestions[j].ItemID]
+		if si != sj {
+			return si > sj
+		}
+		return suggestions[i].ItemID < suggestions[j].ItemID
+	})
+
+	return suggestions
+}
+
+// meanStddev returns the mean and standard deviation of values.
+func meanStddev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,917 @@
+package bringapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultBaseURL is the URL of the Bring REST API.
+	DefaultBaseURL = "https://api.getbring.com/rest/"
+	// DefaultLocaleURL is the URL of Bring's item translations.
+	DefaultLocaleURL = "https://web.getbring.com/locale/"
+	// DefaultUserAgent is sent unless WithUserAgent is used.
+	DefaultUserAgent = "bring-cli/1.0"
+
+	apiKey     = "cof4Nc6D8saplXjE3h3HXqHH8m7VU2i1Gs0g85Sp"
+	httpClient = "android"
+)
+
+// Client is the Bring API client. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	localeURL  string
+	userAgent  string
+	store      TokenStore
+	tokens     TokenSource
+
+	mu          sync.Mutex // guards credentials
+	credentials *Credentials
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL sets the URL of the API, e.g. to use a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// WithLocaleURL sets the URL item translations are loaded from, e.g. to
+// use a test server.
+func WithLocaleURL(localeURL string) Option {
+	return func(c *Client) {
+		if !strings.HasSuffix(localeURL, "/") {
+			localeURL += "/"
+		}
+		c.localeURL = localeURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests. The default has
+// a timeout of 30 seconds.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithCredentials sets the credentials to start with, e.g. from an
+// earlier Login.
+func WithCredentials(creds *Credentials) Option {
+	return func(c *Client) {
+		if creds != nil {
+			copied := *creds
+			c.credentials = &copied
+		}
+	}
+}
+
+// WithTokenStore sets where credentials are persisted. The client loads
+// them from the store when it has none, and saves them after Login and
+// every token refresh.
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) {
+		c.store = store
+	}
+}
+
+// WithTokenSource makes the client take its credentials from source
+// before every request, instead of refreshing them itself. Use it to
+// share credentials that are refreshed elsewhere.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = source
+	}
+}
+
+// NewClient creates a new Bring API client.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		baseURL:   DefaultBaseURL,
+		localeURL: DefaultLocaleURL,
+		userAgent: DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Login authenticates with email and password.
+func (c *Client) Login(email, password string) (*AuthResponse, error) {
+	data := url.Values{}
+	data.Set("email", email)
+	data.Set("password", password)
+
+	req, err := http.NewRequest("POST", c.baseURL+"v2/bringauth", bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-BRING-API-KEY", apiKey)
+	req.Header.Set("X-BRING-CLIENT", httpClient)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var authResp AuthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	// Store credentials
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.credentials = &Credentials{
+		Email:        authResp.Email,
+		UUID:         authResp.UUID,
+		PublicUUID:   authResp.PublicUUID,
+		AccessToken:  authResp.AccessToken,
+		RefreshToken: authResp.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(authResp.ExpiresIn) * time.Second),
+		DefaultList:  authResp.BringListUUID,
+	}
+	if err := c.saveCredentials(); err != nil {
+		return nil, err
+	}
+
+	return &authResp, nil
+}
+
+// RefreshToken refreshes the access token.
+func (c *Client) RefreshToken() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshToken()
+}
+
+// refreshToken refreshes the access token; c.mu must be held. The
+// credentials are replaced instead of changed, so credentials handed out
+// earlier are left as they were.
+func (c *Client) refreshToken() error {
+	if c.credentials == nil {
+		return fmt.Errorf("no credentials available")
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", c.credentials.RefreshToken)
+
+	req, err := http.NewRequest("POST", c.baseURL+"v2/bringauth/token", bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-BRING-API-KEY", apiKey)
+	req.Header.Set("X-BRING-CLIENT", httpClient)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("token refresh failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	refreshed := *c.credentials
+	refreshed.AccessToken = tokenResp.AccessToken
+	refreshed.RefreshToken = tokenResp.RefreshToken
+	refreshed.ExpiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	c.credentials = &refreshed
+
+	if err := c.saveCredentials(); err != nil {
+		return fmt.Errorf("saving refreshed credentials: %w", err)
+	}
+
+	return nil
+}
+
+// saveCredentials saves the credentials to the token store, if any; c.mu
+// must be held.
+func (c *Client) saveCredentials() error {
+	if c.store == nil {
+		return nil
+	}
+	if err := c.store.Save(c.credentials); err != nil {
+		return fmt.Errorf("saving credentials: %w", err)
+	}
+	return nil
+}
+
+// user returns the credentials of the logged in user, refreshing the
+// token if it is about to expire. Credentials come from the token source
+// or, if there is none, are loaded from the token store. The returned
+// credentials are never changed by the client.
+func (c *Client) user() (*Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tokens != nil {
+		creds, err := c.tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("getting token: %w", err)
+		}
+		c.credentials = creds
+	}
+	if c.credentials == nil && c.store != nil {
+		creds, err := c.store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading credentials: %w", err)
+		}
+		c.credentials = creds
+	}
+	if c.credentials == nil || c.credentials.AccessToken == "" {
+		return nil, fmt.Errorf("not authenticated")
+	}
+	if c.tokens == nil && time.Now().After(c.credentials.ExpiresAt.Add(-time.Minute)) {
+		if err := c.refreshToken(); err != nil {
+			return nil, err
+		}
+	}
+	return c.credentials, nil
+}
+
+// doAuthenticatedRequest performs an authenticated HTTP request.
+func (c *Client) doAuthenticatedRequest(method, endpoint string, body interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	contentType := ""
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling body: %w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonBody)
+		contentType = "application/json"
+	}
+
+	return c.sendAuthenticated(method, endpoint, reqBody, contentType)
+}
+
+// doAuthenticatedForm sends form data, which some endpoints expect instead of JSON.
+func (c *Client) doAuthenticatedForm(method, endpoint string, data url.Values) (*http.Response, error) {
+	return c.sendAuthenticated(method, endpoint, bytes.NewBufferString(data.Encode()), "application/x-www-form-urlencoded")
+}
+
+// sendAuthenticated sends a request with the authentication headers set.
+func (c *Client) sendAuthenticated(method, endpoint string, body io.Reader, contentType string) (*http.Response, error) {
+	user, err := c.user()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+user.AccessToken)
+	req.Header.Set("X-BRING-API-KEY", apiKey)
+	req.Header.Set("X-BRING-CLIENT", httpClient)
+	req.Header.Set("X-BRING-USER-UUID", user.UUID)
+	req.Header.Set("User-Agent", c.userAgent)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// GetLists returns all shopping lists for the user.
+func (c *Client) GetLists() (*ListsResponse, error) {
+	user, err := c.user()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAuthenticatedRequest("GET", "bringusers/"+user.UUID+"/lists", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get lists (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var listsResp ListsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listsResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &listsResp, nil
+}
+
+// CreateList creates a shopping list with the given name and theme.
+// An empty theme uses ThemeHome.
+func (c *Client) CreateList(name, theme string) (*ShoppingList, error) {
+	if theme == "" {
+		theme = ThemeHome
+	}
+
+	data := url.Values{}
+	data.Set("name", name)
+	data.Set("theme", theme)
+
+	user, err := c.user()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAuthenticatedForm("POST", "bringusers/"+user.UUID+"/lists", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create list (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var createResp CreateListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&createResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	list := &ShoppingList{ListUUID: createResp.ListUUID, Name: name, Theme: theme}
+	if list.ListUUID == "" {
+		list.ListUUID = createResp.BringListUUID
+	}
+	if createResp.Name != "" {
+		list.Name = createResp.Name
+	}
+	if createResp.Theme != "" {
+		list.Theme = createResp.Theme
+	}
+	return list, nil
+}
+
+// RenameList changes the name of a shopping list.
+func (c *Client) RenameList(listUUID, name string) error {
+	return c.updateList(listUUID, "name", name)
+}
+
+// SetListTheme changes the theme of a shopping list.
+func (c *Client) SetListTheme(listUUID, theme string) error {
+	return c.updateList(listUUID, "theme", theme)
+}
+
+// updateList sets a single property of a shopping list.
+func (c *Client) updateList(listUUID, field, value string) error {
+	data := url.Values{}
+	data.Set(field, value)
+
+	user, err := c.user()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuthenticatedForm("PUT", "bringusers/"+user.UUID+"/lists/"+listUUID, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update list %s (status %d): %s", field, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// DeleteList deletes a shopping list. Lists shared with others are only
+// removed for the current user.
+func (c *Client) DeleteList(listUUID string) error {
+	user, err := c.user()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuthenticatedRequest("DELETE", "bringusers/"+user.UUID+"/lists/"+listUUID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete list (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetListItems returns items in a shopping list.
+func (c *Client) GetListItems(listUUID string) (*ListItemsResponse, error) {
+	resp, err := c.doAuthenticatedRequest("GET", "v2/bringlists/"+listUUID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get list items (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var listResp ListItemsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &listResp, nil
+}
+
+// GetListActivity returns the activity timeline of a shopping list.
+func (c *Client) GetListActivity(listUUID string) (*ActivityResponse, error) {
+	resp, err := c.doAuthenticatedRequest("GET", "v2/bringlists/"+listUUID+"/activity", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get list activity (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var activityResp ActivityResponse
+	if err := json.NewDecoder(resp.Body).Decode(&activityResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &activityResp, nil
+}
+
+// GetListUsers returns the members of a shopping list.
+func (c *Client) GetListUsers(listUUID string) (*ListUsersResponse, error) {
+	resp, err := c.doAuthenticatedRequest("GET", "bringlists/"+listUUID+"/users", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get list users (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var usersResp ListUsersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&usersResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &usersResp, nil
+}
+
+// LookupUser finds the Bring user with the given email address. It returns
+// an error matching ErrUserNotFound if there is none.
+func (c *Client) LookupUser(email string) (*BringUser, error) {
+	resp, err := c.doAuthenticatedRequest("GET", "bringusers?email="+url.QueryEscape(email), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNoContent {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, email)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to look up user (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var user BringUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if user.PublicUUID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, email)
+	}
+	if user.Email == "" {
+		user.Email = email
+	}
+
+	return &user, nil
+}
+
+// InviteToList invites the Bring user with the given email address to a
+// shopping list and returns the invited user. It returns an error matching
+// ErrUserNotFound if the email doesn't belong to a Bring user.
+func (c *Client) InviteToList(listUUID, email string) (*BringUser, error) {
+	user, err := c.LookupUser(email)
+	if err != nil {
+		return nil, err
+	}
+
+	data := url.Values{}
+	data.Set("email", email)
+
+	resp, err := c.doAuthenticatedForm("POST", "bringlists/"+listUUID+"/invitations", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to invite %s (status %d): %s", email, resp.StatusCode, string(body))
+	}
+
+	return user, nil
+}
+
+// RemoveListMember removes the user with the given public UUID from a
+// shopping list.
+func (c *Client) RemoveListMember(listUUID, publicUUID string) error {
+	resp, err := c.doAuthenticatedRequest("DELETE", "bringlists/"+listUUID+"/users/"+publicUUID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to remove member (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetItemDetails returns the details of the items of a shopping list.
+// Only items that have details, e.g. a photo, are included.
+func (c *Client) GetItemDetails(listUUID string) ([]ItemDetail, error) {
+	resp, err := c.doAuthenticatedRequest("GET", "bringlists/"+listUUID+"/details", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get item details (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var details []ItemDetail
+	if err := json.NewDecoder(resp.Body).Decode(&details); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return details, nil
+}
+
+// FindItemDetail returns the details of itemID, or nil if it has none.
+func FindItemDetail(details []ItemDetail, itemID string) *ItemDetail {
+	for i, detail := range details {
+		if strings.EqualFold(detail.ItemID, itemID) {
+			return &details[i]
+		}
+	}
+	return nil
+}
+
+// createItemDetail creates the details of an item, which photos are attached to.
+func (c *Client) createItemDetail(listUUID, itemID string) (*ItemDetail, error) {
+	req := CreateItemDetailRequest{ListUUID: listUUID, ItemID: itemID}
+	resp, err := c.doAuthenticatedRequest("POST", "bringlistitemdetails/", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create item details (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var detail ItemDetail
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &detail, nil
+}
+
+// SetItemImage uploads a photo for an item on a shopping list, replacing
+// any existing one, and returns the URL of the uploaded image.
+func (c *Client) SetItemImage(listUUID, itemID string, image []byte) (string, error) {
+	details, err := c.GetItemDetails(listUUID)
+	if err != nil {
+		return "", err
+	}
+
+	detail := FindItemDetail(details, itemID)
+	if detail == nil {
+		if detail, err = c.createItemDetail(listUUID, itemID); err != nil {
+			return "", err
+		}
+	}
+
+	req := ItemImageRequest{ImageData: base64.StdEncoding.EncodeToString(image)}
+	resp, err := c.doAuthenticatedRequest("PUT", "bringlistitemdetails/"+detail.UUID+"/image", req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to upload image (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var imageResp ItemImageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&imageResp); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	return imageResp.ImageURL, nil
+}
+
+// GetItemImage downloads the photo of an item from its image URL and
+// returns it with its content type, e.g. "image/jpeg".
+func (c *Client) GetItemImage(imageURL string) ([]byte, string, error) {
+	req, err := http.NewRequest("GET", imageURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", fmt.Errorf("failed to download image (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	image, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading image: %w", err)
+	}
+
+	// Fall back to sniffing the image if the server doesn't say
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(image)
+	}
+	return image, contentType, nil
+}
+
+// UpdateItems performs batch update on list items.
+func (c *Client) UpdateItems(listUUID string, changes []ItemChange) error {
+	// Set defaults for items
+	for i := range changes {
+		if changes[i].UUID == "" {
+			changes[i].UUID = uuid.New().String()
+		}
+		// Set location fields to defaults (required by API)
+		changes[i].Accuracy = "0.0"
+		changes[i].Altitude = "0.0"
+		changes[i].Latitude = "0.0"
+		changes[i].Longitude = "0.0"
+	}
+
+	req := BatchUpdateRequest{
+		Changes: changes,
+		Sender:  "",
+	}
+
+	resp, err := c.doAuthenticatedRequest("PUT", "v2/bringlists/"+listUUID+"/items", req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to update items (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// AddItem adds an item to a list.
+func (c *Client) AddItem(listUUID, itemName, spec string) error {
+	return c.UpdateItems(listUUID, []ItemChange{
+		{ItemID: itemName, Spec: spec, Operation: OperationAdd},
+	})
+}
+
+// CompleteItem marks an item as completed.
+func (c *Client) CompleteItem(listUUID, itemName string) error {
+	return c.UpdateItems(listUUID, []ItemChange{
+		{ItemID: itemName, Operation: OperationComplete},
+	})
+}
+
+// RemoveItem removes an item from a list.
+// It first fetches the list to find the item's UUID for proper removal.
+// Removing an item that isn't on the list does nothing; use RemoveItems
+// to find out whether it was.
+func (c *Client) RemoveItem(listUUID, itemName string) error {
+	_, err := c.RemoveItems(listUUID, []string{itemName}, nil)
+	return err
+}
+
+// RemoveItems removes several items from a list in a single batch.
+// It fetches the list once, resolves every name to the item's UUID with
+// match (exact ItemID matching if nil) and removes all items found.
+// The returned results report what happened to each requested name.
+func (c *Client) RemoveItems(listUUID string, names []string, match ItemMatcher) ([]ItemResult, error) {
+	listItems, err := c.GetListItems(listUUID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching list items: %w", err)
+	}
+
+	changes, results := PlanRemoval(listItems, names, match)
+	if len(changes) == 0 {
+		return results, nil
+	}
+
+	if err := c.UpdateItems(listUUID, changes); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Notify sends a notification to list users.
+func (c *Client) Notify(listUUID, notificationType string, items []string) error {
+	if err := ValidateNotification(notificationType, items); err != nil {
+		return err
+	}
+
+	user, err := c.user()
+	if err != nil {
+		return err
+	}
+
+	return c.sendNotification(listUUID, NotifyRequest{
+		ListNotificationType: notificationType,
+		SenderPublicUserUUID: user.PublicUUID,
+		Arguments:            items,
+	})
+}
+
+// React sends a reaction to an activity event to the member who caused it.
+func (c *Client) React(listUUID, receiverPublicUUID string, reaction ActivityReaction) error {
+	switch reaction.ReactionType {
+	case ReactionThumbsUp, ReactionMonocle, ReactionDrooling, ReactionHeart:
+	default:
+		return fmt.Errorf("invalid reaction: %s", reaction.ReactionType)
+	}
+	if reaction.ModuleUUID == "" || receiverPublicUUID == "" {
+		return fmt.Errorf("a reaction needs an activity event and its author")
+	}
+
+	user, err := c.user()
+	if err != nil {
+		return err
+	}
+
+	return c.sendNotification(listUUID, NotifyRequest{
+		ListNotificationType:       NotifyActivityReaction,
+		SenderPublicUserUUID:       user.PublicUUID,
+		ReceiverPublicUserUUID:     receiverPublicUUID,
+		ListActivityStreamReaction: &reaction,
+	})
+}
+
+// sendNotification posts a notification to the members of a list.
+func (c *Client) sendNotification(listUUID string, req NotifyRequest) error {
+	resp, err := c.doAuthenticatedRequest("POST", "v2/bringnotifications/lists/"+listUUID, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to send notification (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// ValidateNotification checks that a notification type can be sent with
+// Notify and gets the item arguments it needs: urgent messages name at
+// least one item, the other types take none.
+func ValidateNotification(notificationType string, items []string) error {
+	switch notificationType {
+	case NotifyGoingShopping, NotifyChangedList, NotifyShoppingDone:
+		if len(items) > 0 {
+			return fmt.Errorf("%s notifications don't take items", notificationType)
+		}
+	case NotifyUrgentMessage:
+		if len(items) == 0 {
+			return fmt.Errorf("%s notifications need at least one item", notificationType)
+		}
+	case NotifyActivityReaction:
+		return fmt.Errorf("%s notifications are sent with React", notificationType)
+	default:
+		return fmt.Errorf("unknown notification type: %s", notificationType)
+	}
+	return nil
+}
+
+// GetArticleTranslations returns the catalog names of items in a locale
+// (e.g. "en-US"), keyed by ItemID. ItemIDs are the German catalog names.
+func (c *Client) GetArticleTranslations(locale string) (map[string]string, error) {
+	req, err := http.NewRequest("GET", c.localeURL+"articles."+locale+".json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get translations (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	// The file also contains non-string entries, which are skipped
+	var raw map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	translations := make(map[string]string, len(raw))
+	for itemID, value := range raw {
+		if name, ok := value.(string); ok {
+			translations[itemID] = name
+		}
+	}
+
+	return translations, nil
+}
+
+// GetCredentials returns a copy of the current credentials, or nil if
+// there are none.
+func (c *Client) GetCredentials() *Credentials {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.credentials == nil {
+		return nil
+	}
+	creds := *c.credentials
+	return &creds
+}
@@ -0,0 +1,690 @@
+package bringapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+var (
+	testEmail    = os.Getenv("BRING_TEST_EMAIL")
+	testPassword = os.Getenv("BRING_TEST_PASSWORD")
+	testListName = os.Getenv("BRING_TEST_LIST_NAME")
+)
+
+func skipIfNoCredentials(t *testing.T) {
+	if testEmail == "" || testPassword == "" {
+		t.Skip("Skipping test: BRING_TEST_EMAIL and BRING_TEST_PASSWORD environment variables not set")
+	}
+}
+
+// getListUUIDByName finds a list by name and returns its UUID
+func getListUUIDByName(client *bringapi.Client, name string) (string, error) {
+	lists, err := client.GetLists()
+	if err != nil {
+		return "", err
+	}
+	for _, list := range lists.Lists {
+		if list.Name == name {
+			return list.ListUUID, nil
+		}
+	}
+	// Fallback to default list
+	creds := client.GetCredentials()
+	if creds != nil {
+		return creds.DefaultList, nil
+	}
+	return "", nil
+}
+
+func TestLogin(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	authResp, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	if authResp.UUID == "" {
+		t.Error("Expected UUID to be set")
+	}
+	if authResp.AccessToken == "" {
+		t.Error("Expected AccessToken to be set")
+	}
+	if authResp.RefreshToken == "" {
+		t.Error("Expected RefreshToken to be set")
+	}
+	if authResp.Email != testEmail {
+		t.Errorf("Expected email %s, got %s", testEmail, authResp.Email)
+	}
+
+	t.Logf("Logged in as: %s", authResp.Email)
+	t.Logf("Default list UUID: %s", authResp.BringListUUID)
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	client := bringapi.NewClient()
+	_, err := client.Login("invalid@example.com", "wrongpassword")
+	if err == nil {
+		t.Error("Expected error for invalid credentials")
+	}
+}
+
+func TestGetLists(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	lists, err := client.GetLists()
+	if err != nil {
+		t.Fatalf("GetLists failed: %v", err)
+	}
+
+	if len(lists.Lists) == 0 {
+		t.Error("Expected at least one list")
+	}
+
+	t.Logf("Found %d lists:", len(lists.Lists))
+	for _, list := range lists.Lists {
+		t.Logf("  - %s (%s)", list.Name, list.ListUUID)
+	}
+}
+
+func TestGetListByName(t *testing.T) {
+	skipIfNoCredentials(t)
+	if testListName == "" {
+		t.Skip("Skipping test: BRING_TEST_LIST_NAME not set")
+	}
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	lists, err := client.GetLists()
+	if err != nil {
+		t.Fatalf("GetLists failed: %v", err)
+	}
+
+	var targetList *bringapi.ShoppingList
+	for _, list := range lists.Lists {
+		if list.Name == testListName {
+			targetList = &list
+			break
+		}
+	}
+
+	if targetList == nil {
+		t.Fatalf("List '%s' not found", testListName)
+	}
+
+	t.Logf("Found list '%s' with UUID: %s", targetList.Name, targetList.ListUUID)
+}
+
+func TestGetListItems(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		t.Fatalf("GetListItems failed: %v", err)
+	}
+
+	t.Logf("List status: %s", items.Status)
+	t.Logf("Items to purchase: %d", len(items.Items.Purchase))
+	t.Logf("Recently completed: %d", len(items.Items.Recently))
+
+	for _, item := range items.Items.Purchase {
+		t.Logf("  [TO BUY] %s - %s", item.ItemID, item.Specification)
+	}
+}
+
+func TestAddAndRemoveItem(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	testItem := "Testprodukt"
+	testSpec := "2 Stück (Test)"
+
+	// Add item
+	t.Log("Adding test item...")
+	err = client.AddItem(listUUID, testItem, testSpec)
+	if err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+
+	// Verify item was added
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		t.Fatalf("GetListItems failed: %v", err)
+	}
+
+	found := false
+	for _, item := range items.Items.Purchase {
+		if item.ItemID == testItem {
+			found = true
+			if item.Specification != testSpec {
+				t.Errorf("Expected spec '%s', got '%s'", testSpec, item.Specification)
+			}
+			break
+		}
+	}
+	if !found {
+		t.Error("Test item not found after adding")
+	}
+
+	// Remove item
+	t.Log("Removing test item...")
+	err = client.RemoveItem(listUUID, testItem)
+	if err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+
+	// Verify item was removed (check both purchase and recently lists)
+	items, err = client.GetListItems(listUUID)
+	if err != nil {
+		t.Fatalf("GetListItems failed: %v", err)
+	}
+
+	foundInPurchase := false
+	for _, item := range items.Items.Purchase {
+		if item.ItemID == testItem {
+			foundInPurchase = true
+			break
+		}
+	}
+
+	foundInRecently := false
+	for _, item := range items.Items.Recently {
+		if item.ItemID == testItem {
+			foundInRecently = true
+			break
+		}
+	}
+
+	if foundInPurchase {
+		t.Log("Note: Item still in purchase list (API eventual consistency)")
+	}
+	if foundInRecently {
+		t.Log("Note: Item moved to recently list")
+	}
+
+	t.Log("Add/Remove test passed")
+}
+
+func TestCompleteItem(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	testItem := "Testabschluss"
+
+	// Add item first
+	t.Log("Adding test item...")
+	err = client.AddItem(listUUID, testItem, "")
+	if err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+
+	// Complete item
+	t.Log("Completing test item...")
+	err = client.CompleteItem(listUUID, testItem)
+	if err != nil {
+		t.Fatalf("CompleteItem failed: %v", err)
+	}
+
+	// Verify item is in recently completed
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		t.Fatalf("GetListItems failed: %v", err)
+	}
+
+	found := false
+	for _, item := range items.Items.Recently {
+		if item.ItemID == testItem {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("Test item not found in recently completed")
+	}
+
+	// Cleanup: remove item
+	_ = client.RemoveItem(listUUID, testItem)
+
+	t.Log("Complete test passed")
+}
+
+func TestBatchUpdate(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	// Use unique test items to avoid conflicts with previous runs
+	testItems := []string{"BatchTest1", "BatchTest2", "BatchTest3"}
+
+	// Clean up any existing test items first
+	for _, item := range testItems {
+		_ = client.RemoveItem(listUUID, item)
+	}
+
+	// Get initial count
+	initialItems, err := client.GetListItems(listUUID)
+	if err != nil {
+		t.Fatalf("GetListItems failed: %v", err)
+	}
+	initialCount := len(initialItems.Items.Purchase)
+
+	// Add multiple items
+	t.Log("Adding multiple items...")
+	var changes []bringapi.ItemChange
+	for _, item := range testItems {
+		changes = append(changes, bringapi.ItemChange{
+			ItemID:    item,
+			Spec:      "batch test",
+			Operation: bringapi.OperationAdd,
+		})
+	}
+
+	err = client.UpdateItems(listUUID, changes)
+	if err != nil {
+		t.Fatalf("Batch add failed: %v", err)
+	}
+
+	// Verify items were added
+	items, err := client.GetListItems(listUUID)
+	if err != nil {
+		t.Fatalf("GetListItems failed: %v", err)
+	}
+
+	// Check that we have more items than before
+	newCount := len(items.Items.Purchase)
+	if newCount < initialCount+len(testItems) {
+		t.Errorf("Expected at least %d items, got %d", initialCount+len(testItems), newCount)
+	}
+
+	// Verify our specific items exist
+	for _, testItem := range testItems {
+		found := false
+		for _, item := range items.Items.Purchase {
+			if item.ItemID == testItem {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Item %s not found after batch add", testItem)
+		}
+	}
+
+	// Remove all test items
+	t.Log("Removing multiple items...")
+	for _, item := range testItems {
+		if err := client.RemoveItem(listUUID, item); err != nil {
+			t.Logf("Warning: failed to remove %s: %v", item, err)
+		}
+	}
+
+	t.Log("Batch update test passed")
+}
+
+func TestTokenRefresh(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	store := &memoryStore{}
+	client := bringapi.NewClient(bringapi.WithTokenStore(store))
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	// Force token refresh
+	err = client.RefreshToken()
+	if err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+
+	if store.saves != 2 {
+		t.Errorf("Expected the credentials to be saved after login and refresh, got %d saves", store.saves)
+	}
+
+	// Verify we can still make API calls
+	_, err = client.GetLists()
+	if err != nil {
+		t.Fatalf("GetLists after refresh failed: %v", err)
+	}
+
+	t.Log("Token refresh test passed")
+}
+
+func TestGetListActivity(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	activity, err := client.GetListActivity(listUUID)
+	if err != nil {
+		t.Fatalf("GetListActivity failed: %v", err)
+	}
+
+	t.Logf("Activity events: %d (total %d)", len(activity.Timeline), activity.TotalEvents)
+	for _, event := range activity.Timeline {
+		t.Logf("  [%s] %s by %s: %d items", event.Content.SessionDate.Format("2006-01-02 15:04"),
+			event.Type, event.Content.PublicUserUUID, len(event.Content.Items))
+	}
+}
+
+func TestGetListUsers(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	users, err := client.GetListUsers(listUUID)
+	if err != nil {
+		t.Fatalf("GetListUsers failed: %v", err)
+	}
+
+	found := false
+	for _, user := range users.Users {
+		if user.PublicUUID == client.GetCredentials().PublicUUID {
+			found = true
+		}
+		t.Logf("  - %s (%s)", user.Name, user.PublicUUID)
+	}
+	if !found {
+		t.Error("Expected the logged in user to be a member of the list")
+	}
+}
+
+func TestGetItemDetails(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	details, err := client.GetItemDetails(listUUID)
+	if err != nil {
+		t.Fatalf("GetItemDetails failed: %v", err)
+	}
+
+	for _, detail := range details {
+		if detail.ListUUID != "" && detail.ListUUID != listUUID {
+			t.Errorf("Expected details of list %s, got %s", listUUID, detail.ListUUID)
+		}
+		t.Logf("  - %s (image: %s)", detail.ItemID, detail.ImageURL)
+	}
+}
+
+func TestCreateRenameDeleteList(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	name := "bring-cli test " + time.Now().Format("150405")
+	list, err := client.CreateList(name, bringapi.ThemeOffice)
+	if err != nil {
+		t.Fatalf("CreateList failed: %v", err)
+	}
+	t.Logf("Created list %s (%s)", list.Name, list.ListUUID)
+
+	renamed := name + " renamed"
+	if err := client.RenameList(list.ListUUID, renamed); err != nil {
+		t.Errorf("RenameList failed: %v", err)
+	}
+
+	lists, err := client.GetLists()
+	if err != nil {
+		t.Fatalf("GetLists failed: %v", err)
+	}
+	found := false
+	for _, l := range lists.Lists {
+		if l.ListUUID == list.ListUUID {
+			found = true
+			if l.Name != renamed {
+				t.Errorf("Expected list to be renamed to %q, got %q", renamed, l.Name)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected the created list to be returned by GetLists")
+	}
+
+	if err := client.DeleteList(list.ListUUID); err != nil {
+		t.Fatalf("DeleteList failed: %v", err)
+	}
+}
+
+func TestListTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"office", bringapi.ThemeOffice, false},
+		{"BBQ", bringapi.ThemeBBQ, false},
+		{bringapi.ThemeChristmas, bringapi.ThemeChristmas, false},
+		{"beach", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := bringapi.ListTheme(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ListTheme(%q): unexpected error %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("ListTheme(%q): expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+
+	if name := bringapi.ThemeName(bringapi.ThemeSchool); name != "school" {
+		t.Errorf("Expected short theme name school, got %s", name)
+	}
+}
+
+func TestInviteUnknownUser(t *testing.T) {
+	skipIfNoCredentials(t)
+
+	client := bringapi.NewClient()
+	_, err := client.Login(testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	listUUID, err := getListUUIDByName(client, testListName)
+	if err != nil {
+		t.Fatalf("Failed to get list UUID: %v", err)
+	}
+
+	_, err = client.InviteToList(listUUID, "no-such-user-"+time.Now().Format("150405")+"@example.invalid")
+	if !errors.Is(err, bringapi.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestValidateNotification(t *testing.T) {
+	tests := []struct {
+		notificationType string
+		items            []string
+		wantErr          bool
+	}{
+		{bringapi.NotifyGoingShopping, nil, false},
+		{bringapi.NotifyChangedList, []string{"Milch"}, true},
+		{bringapi.NotifyUrgentMessage, []string{"Milch"}, false},
+		{bringapi.NotifyUrgentMessage, nil, true},
+		{bringapi.NotifyActivityReaction, nil, true},
+		{"BUY_EVERYTHING", nil, true},
+	}
+
+	for _, tt := range tests {
+		err := bringapi.ValidateNotification(tt.notificationType, tt.items)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateNotification(%s, %v): expected error %v, got %v", tt.notificationType, tt.items, tt.wantErr, err)
+		}
+	}
+}
+
+func TestPlanRemoval(t *testing.T) {
+	list := &bringapi.ListItemsResponse{
+		Items: bringapi.Items{
+			Purchase: []bringapi.ListItem{{UUID: "u1", ItemID: "Milch"}, {ItemID: "Eier"}, {ItemID: "Käse"}},
+			Recently: []bringapi.ListItem{{UUID: "u2", ItemID: "Brot"}},
+		},
+	}
+
+	changes, results := bringapi.PlanRemoval(list, []string{"Milch", "Brot", "Bier", "Milch", "Eier", "Käse", "Eier"}, nil)
+
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 changes, got %d", len(changes))
+	}
+	for i, want := range []bringapi.ItemChange{
+		{ItemID: "Milch", UUID: "u1"},
+		{ItemID: "Brot", UUID: "u2"},
+		{ItemID: "Eier"},
+		{ItemID: "Käse"},
+	} {
+		want.Operation = bringapi.OperationRemove
+		if changes[i] != want {
+			t.Errorf("Expected change %d to be %+v, got %+v", i, want, changes[i])
+		}
+	}
+
+	expected := []string{bringapi.ItemRemoved, bringapi.ItemRemoved, bringapi.ItemNotFound, bringapi.ItemRemoved, bringapi.ItemRemoved, bringapi.ItemRemoved, bringapi.ItemRemoved}
+	for i, status := range expected {
+		if results[i].Status != status {
+			t.Errorf("Expected result %d to be %s, got %s", i, status, results[i].Status)
+		}
+	}
+}
+
+func TestGetItemImageContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"header", "image/png; charset=binary", "image/png"},
+		{"sniffed", "application/octet-stream", "image/png"},
+		{"missing", "", "image/png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header()["Content-Type"] = []string{tt.header}
+				w.Write(png)
+			}))
+			defer server.Close()
+
+			image, contentType, err := bringapi.NewClient().GetItemImage(server.URL + "/image.png")
+			if err != nil {
+				t.Fatalf("GetItemImage failed: %v", err)
+			}
+			if string(image) != string(png) || contentType != tt.want {
+				t.Errorf("GetItemImage() = %q, %q, want the image and %q", image, contentType, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetArticleTranslations(t *testing.T) {
+	var path, userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, userAgent = r.URL.Path, r.UserAgent()
+		w.Write([]byte(`{"Milch": "Milk", "Brot": "Bread", "$sections": {"Brot & Gebäck": "Bread & Pastries"}}`))
+	}))
+	defer server.Close()
+
+	client := bringapi.NewClient(bringapi.WithLocaleURL(server.URL+"/locale"), bringapi.WithUserAgent("test/1.0"))
+	translations, err := client.GetArticleTranslations("en-US")
+	if err != nil {
+		t.Fatalf("GetArticleTranslations failed: %v", err)
+	}
+	if path != "/locale/articles.en-US.json" || userAgent != "test/1.0" {
+		t.Errorf("requested %s as %q, want /locale/articles.en-US.json as test/1.0", path, userAgent)
+	}
+	want := map[string]string{"Milch": "Milk", "Brot": "Bread"}
+	if !reflect.DeepEqual(translations, want) {
+		t.Errorf("translations = %v, want %v", translations, want)
+	}
+}
@@ -0,0 +1,20 @@
+// Package bringapi is a client for the Bring! shopping list API, as used
+// by the bring CLI.
+//
+// Create a client with NewClient and log in, or pass credentials saved
+// from an earlier login:
+//
+//	client := bringapi.NewClient()
+//	if _, err := client.Login(email, password); err != nil {
+//		return err
+//	}
+//	lists, err := client.GetLists()
+//
+// Options change the base URL, HTTP client and user agent. Access tokens
+// expire after a while; the client refreshes them and saves the new
+// credentials to the TokenStore set with WithTokenStore. Services that
+// manage tokens themselves can provide them with WithTokenSource instead.
+//
+// A Client is safe for concurrent use; concurrent requests share a single
+// token refresh.
+package bringapi
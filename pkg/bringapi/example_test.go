@@ -0,0 +1,63 @@
+package bringapi_test
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+func ExampleNewClient() {
+	client := bringapi.NewClient(
+		bringapi.WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
+		bringapi.WithUserAgent("my-service/2.1"),
+	)
+	if _, err := client.Login(os.Getenv("BRING_EMAIL"), os.Getenv("BRING_PASSWORD")); err != nil {
+		log.Fatal(err)
+	}
+
+	lists, err := client.GetLists()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, list := range lists.Lists {
+		fmt.Println(list.Name)
+	}
+}
+
+// A TokenStore keeps the credentials across runs: the client loads them
+// when it needs them and saves them again after refreshing the token.
+func ExampleWithTokenStore() {
+	store := &memoryStore{}
+	client := bringapi.NewClient(bringapi.WithTokenStore(store))
+
+	// Credentials are saved after logging in once
+	if store.creds == nil {
+		if _, err := client.Login(os.Getenv("BRING_EMAIL"), os.Getenv("BRING_PASSWORD")); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := client.AddItem(store.creds.DefaultList, "Milch", "1.5%"); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Services that refresh tokens centrally can hand them to the client with
+// a TokenSource.
+func ExampleWithTokenSource() {
+	creds := &bringapi.Credentials{
+		UUID:        os.Getenv("BRING_USER_UUID"),
+		AccessToken: os.Getenv("BRING_ACCESS_TOKEN"),
+	}
+	client := bringapi.NewClient(bringapi.WithTokenSource(bringapi.StaticTokenSource(creds)))
+
+	items, err := client.GetListItems(os.Getenv("BRING_LIST"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(len(items.Items.Purchase), "items to buy")
+}
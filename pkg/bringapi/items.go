@@ -0,0 +1,270 @@
+package bringapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrItemNotFound is returned when a requested item is not on a list.
+var ErrItemNotFound = errors.New("item not found")
+
+// Statuses of an ItemResult.
+const (
+	ItemAdded     = "added"
+	ItemMerged    = "merged"
+	ItemCompleted = "completed"
+	ItemRemoved   = "removed"
+	ItemFlagged   = "flagged"
+	ItemRestored  = "restored"
+	ItemSkipped   = "skipped"
+	ItemNotFound  = "not_found"
+	ItemFailed    = "failed"
+)
+
+// ItemResult reports what happened to a single requested item in a batch.
+type ItemResult struct {
+	Name   string `json:"name"`
+	ItemID string `json:"itemId,omitempty"`
+	UUID   string `json:"uuid,omitempty"`
+	Status string `json:"status"`
+	Spec   string `json:"spec,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// OK reports whether the item was handled without error. Skipped items
+// count as handled.
+func (r ItemResult) OK() bool {
+	return r.Status != ItemNotFound && r.Status != ItemFailed
+}
+
+// ItemMatcher resolves a requested item name to one of items. It returns
+// an error matching ErrItemNotFound if no item matches.
+type ItemMatcher func(name string, items []ListItem) (*ListItem, error)
+
+// MatchExact is an ItemMatcher that only accepts the exact ItemID.
+func MatchExact(name string, items []ListItem) (*ListItem, error) {
+	for i, item := range items {
+		if item.ItemID == name {
+			return &items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrItemNotFound, name)
+}
+
+// Item locations on a list.
+const (
+	LocationPurchase = "purchase"
+	LocationRecently = "recently"
+)
+
+// FindItem returns the item with the given ItemID and where it is on the list.
+// Matching is case-insensitive. Items on the purchase list take precedence.
+// It returns nil and an empty location if the item is not on the list.
+func (r *ListItemsResponse) FindItem(itemID string) (*ListItem, string) {
+	for i, item := range r.Items.Purchase {
+		if strings.EqualFold(item.ItemID, itemID) {
+			return &r.Items.Purchase[i], LocationPurchase
+		}
+	}
+	for i, item := range r.Items.Recently {
+		if strings.EqualFold(item.ItemID, itemID) {
+			return &r.Items.Recently[i], LocationRecently
+		}
+	}
+	return nil, ""
+}
+
+// RestoreChange returns the change that puts an item back into a previous
+// state. An empty location means the item was not on the list, so the
+// returned change removes it.
+func RestoreChange(item ListItem, location string) ItemChange {
+	change := ItemChange{
+		ItemID: item.ItemID,
+		Spec:   item.Specification,
+		UUID:   item.UUID,
+	}
+	switch location {
+	case LocationPurchase:
+		change.Operation = OperationAdd
+	case LocationRecently:
+		change.Operation = OperationComplete
+	default:
+		change.Operation = OperationRemove
+	}
+	return change
+}
+
+// Apply updates the list in place as if the change had been sent to the
+// server. It is used to keep a local copy of a list in sync with batched
+// changes that have not been fetched back yet.
+func (r *ListItemsResponse) Apply(change ItemChange) {
+	prior, location := r.FindItem(change.ItemID)
+
+	if change.Operation == OperationAttributeUpdate {
+		if prior != nil && change.Attribute != nil {
+			prior.SetAttribute(*change.Attribute)
+		}
+		return
+	}
+
+	item := ListItem{
+		UUID:          change.UUID,
+		ItemID:        change.ItemID,
+		Specification: change.Spec,
+	}
+	if prior != nil {
+		if item.UUID == "" {
+			item.UUID = prior.UUID
+		}
+		item.Attributes = prior.Attributes
+
+		// Updating an item on the purchase list keeps its position
+		if location == LocationPurchase && change.Operation == OperationAdd {
+			*prior = item
+			return
+		}
+	}
+
+	r.Items.Purchase = removeItem(r.Items.Purchase, change.ItemID)
+	r.Items.Recently = removeItem(r.Items.Recently, change.ItemID)
+
+	switch change.Operation {
+	case OperationAdd:
+		r.Items.Purchase = append(r.Items.Purchase, item)
+	case OperationComplete:
+		r.Items.Recently = append([]ListItem{item}, r.Items.Recently...)
+	}
+}
+
+// Conditions returns the purchase conditions of the item. Items without
+// the attribute, or with content that isn't valid, have none set.
+func (i ListItem) Conditions() PurchaseConditions {
+	for _, attr := range i.Attributes {
+		if attr.Type == AttributePurchaseConditions && attr.Content != nil {
+			var conditions PurchaseConditions
+			if err := json.Unmarshal(attr.Content, &conditions); err != nil {
+				return PurchaseConditions{}
+			}
+			return conditions
+		}
+	}
+	return PurchaseConditions{}
+}
+
+// ConditionsAttribute returns the attribute holding purchase conditions.
+func ConditionsAttribute(conditions PurchaseConditions) Attribute {
+	content, _ := json.Marshal(conditions) // a struct of bools always encodes
+	return Attribute{Type: AttributePurchaseConditions, Content: content}
+}
+
+// SetAttribute replaces the item's attribute of the same type, or adds it.
+func (i *ListItem) SetAttribute(attr Attribute) {
+	for n := range i.Attributes {
+		if i.Attributes[n].Type == attr.Type {
+			i.Attributes[n] = attr
+			return
+		}
+	}
+	i.Attributes = append(i.Attributes, attr)
+}
+
+// Badges returns the names of the conditions that are set.
+func (c PurchaseConditions) Badges() []string {
+	var badges []string
+	if c.Urgent {
+		badges = append(badges, "urgent")
+	}
+	if c.Convenient {
+		badges = append(badges, "convenient")
+	}
+	if c.Discounted {
+		badges = append(badges, "discounted")
+	}
+	return badges
+}
+
+// Includes reports whether every condition set in want is also set in c.
+func (c PurchaseConditions) Includes(want PurchaseConditions) bool {
+	return (!want.Urgent || c.Urgent) &&
+		(!want.Convenient || c.Convenient) &&
+		(!want.Discounted || c.Discounted)
+}
+
+// ConditionsChange returns the change that sets the purchase conditions of
+// an item. The item is identified by its UUID, so a change adding the item
+// in the same batch must use the same UUID.
+func ConditionsChange(itemID, uuid string, conditions PurchaseConditions) ItemChange {
+	attr := ConditionsAttribute(conditions)
+	return ItemChange{
+		ItemID:    itemID,
+		UUID:      uuid,
+		Operation: OperationAttributeUpdate,
+		Attribute: &attr,
+	}
+}
+
+// ItemKey returns the key identifying an item on a list, e.g. to plan a
+// single change per item: its UUID, or its ItemID for items without one.
+func ItemKey(item ListItem) string {
+	if item.UUID == "" {
+		return item.ItemID
+	}
+	return item.UUID
+}
+
+// removeItem returns items without the item matching itemID.
+func removeItem(items []ListItem, itemID string) []ListItem {
+	kept := items[:0]
+	for _, item := range items {
+		if !strings.EqualFold(item.ItemID, itemID) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// PlanRemoval resolves names against the purchase and recently completed
+// items of a list and returns the changes that remove every item found,
+// along with a result for each name. Names resolving to the same item
+// produce a single change.
+func PlanRemoval(list *ListItemsResponse, names []string, match ItemMatcher) ([]ItemChange, []ItemResult) {
+	if match == nil {
+		match = MatchExact
+	}
+	onList := append(append([]ListItem{}, list.Items.Purchase...), list.Items.Recently...)
+
+	var changes []ItemChange
+	results := make([]ItemResult, 0, len(names))
+	planned := make(map[string]bool)
+	for _, name := range names {
+		result := ItemResult{Name: name}
+
+		item, err := match(name, onList)
+		switch {
+		case errors.Is(err, ErrItemNotFound):
+			result.Status = ItemNotFound
+			result.Error = err.Error()
+		case err != nil:
+			result.Status = ItemFailed
+			result.Error = err.Error()
+		default:
+			result.Status = ItemRemoved
+			result.ItemID = item.ItemID
+			result.UUID = item.UUID
+			if key := ItemKey(*item); !planned[key] {
+				planned[key] = true
+				changes = append(changes, ItemChange{
+					ItemID:    item.ItemID,
+					UUID:      item.UUID,
+					Operation: OperationRemove,
+				})
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return changes, results
+}
@@ -0,0 +1,191 @@
+package bringapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+func TestDecodeAttributes(t *testing.T) {
+	data := `{"uuid": "list-1", "items": {"purchase": [
+		{"uuid": "a", "itemId": "Milch", "attributes": [
+			{"type": "SOMETHING_NEW", "content": ["not", "conditions"]},
+			{"type": "PURCHASE_CONDITIONS", "content": {"urgent": true, "convenient": false, "discounted": true}}
+		]},
+		{"uuid": "b", "itemId": "Brot", "attributes": [{"type": "PURCHASE_CONDITIONS", "content": "invalid"}]}
+	], "recently": []}}`
+
+	var list bringapi.ListItemsResponse
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Expected unknown attributes to decode, got %v", err)
+	}
+
+	want := bringapi.PurchaseConditions{Urgent: true, Discounted: true}
+	if got := list.Items.Purchase[0].Conditions(); got != want {
+		t.Errorf("Conditions() = %+v, want %+v", got, want)
+	}
+	if got := list.Items.Purchase[1].Conditions(); got != (bringapi.PurchaseConditions{}) {
+		t.Errorf("Expected invalid conditions to be ignored, got %+v", got)
+	}
+
+	// Conditions survive encoding a change
+	change := bringapi.ConditionsChange("Milch", "a", want)
+	item := bringapi.ListItem{Attributes: []bringapi.Attribute{*change.Attribute}}
+	if got := item.Conditions(); got != want {
+		t.Errorf("Conditions() of the change = %+v, want %+v", got, want)
+	}
+}
+
+// testList returns a list with Milch and Brot to purchase and Butter
+// recently completed.
+func testList() *bringapi.ListItemsResponse {
+	return &bringapi.ListItemsResponse{Items: bringapi.Items{
+		Purchase: []bringapi.ListItem{
+			{UUID: "a", ItemID: "Milch", Specification: "2L"},
+			{UUID: "b", ItemID: "Brot"},
+		},
+		Recently: []bringapi.ListItem{
+			{UUID: "c", ItemID: "Butter"},
+		},
+	}}
+}
+
+// describe summarizes a list as "purchase | recently", with items as
+// ItemID:Specification:UUID and their flags in brackets.
+func describe(list *bringapi.ListItemsResponse) string {
+	format := func(items []bringapi.ListItem) string {
+		var parts []string
+		for _, item := range items {
+			part := fmt.Sprintf("%s:%s:%s", item.ItemID, item.Specification, item.UUID)
+			if badges := item.Conditions().Badges(); len(badges) > 0 {
+				part += "[" + strings.Join(badges, ",") + "]"
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, " ")
+	}
+	return format(list.Items.Purchase) + " | " + format(list.Items.Recently)
+}
+
+func TestFindItem(t *testing.T) {
+	list := testList()
+	list.Items.Recently = append(list.Items.Recently, bringapi.ListItem{UUID: "d", ItemID: "Brot"})
+
+	tests := []struct {
+		itemID   string
+		uuid     string
+		location string
+	}{
+		{"Milch", "a", bringapi.LocationPurchase},
+		{"milch", "a", bringapi.LocationPurchase},
+		{"BUTTER", "c", bringapi.LocationRecently},
+		{"Brot", "b", bringapi.LocationPurchase}, // purchase takes precedence
+		{"Bier", "", ""},
+	}
+
+	for _, tt := range tests {
+		item, location := list.FindItem(tt.itemID)
+		uuid := ""
+		if item != nil {
+			uuid = item.UUID
+		}
+		if uuid != tt.uuid || location != tt.location {
+			t.Errorf("FindItem(%q) = %q in %q, want %q in %q", tt.itemID, uuid, location, tt.uuid, tt.location)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		change bringapi.ItemChange
+		want   string
+	}{
+		{
+			name:   "add new item",
+			change: bringapi.ItemChange{ItemID: "Eier", UUID: "e", Operation: bringapi.OperationAdd},
+			want:   "Milch:2L:a Brot::b Eier::e | Butter::c",
+		},
+		{
+			name:   "update specification in place",
+			change: bringapi.ItemChange{ItemID: "Milch", Spec: "bio", Operation: bringapi.OperationAdd},
+			want:   "Milch:bio:a Brot::b | Butter::c",
+		},
+		{
+			name:   "complete",
+			change: bringapi.ItemChange{ItemID: "Milch", Spec: "2L", Operation: bringapi.OperationComplete},
+			want:   "Brot::b | Milch:2L:a Butter::c",
+		},
+		{
+			name:   "add recently completed item",
+			change: bringapi.ItemChange{ItemID: "Butter", Operation: bringapi.OperationAdd},
+			want:   "Milch:2L:a Brot::b Butter::c | ",
+		},
+		{
+			name:   "remove",
+			change: bringapi.ItemChange{ItemID: "brot", Operation: bringapi.OperationRemove},
+			want:   "Milch:2L:a | Butter::c",
+		},
+		{
+			name:   "set conditions",
+			change: bringapi.ConditionsChange("Milch", "a", bringapi.PurchaseConditions{Urgent: true}),
+			want:   "Milch:2L:a[urgent] Brot::b | Butter::c",
+		},
+		{
+			name:   "set conditions of missing item",
+			change: bringapi.ConditionsChange("Bier", "x", bringapi.PurchaseConditions{Urgent: true}),
+			want:   "Milch:2L:a Brot::b | Butter::c",
+		},
+	}
+
+	for _, tt := range tests {
+		list := testList()
+		list.Apply(tt.change)
+		if got := describe(list); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRestoreChange(t *testing.T) {
+	tests := []struct {
+		location  string
+		operation string
+	}{
+		{bringapi.LocationPurchase, bringapi.OperationAdd},
+		{bringapi.LocationRecently, bringapi.OperationComplete},
+		{"", bringapi.OperationRemove},
+	}
+
+	item := bringapi.ListItem{UUID: "a", ItemID: "Milch", Specification: "2L"}
+	for _, tt := range tests {
+		change := bringapi.RestoreChange(item, tt.location)
+		want := bringapi.ItemChange{ItemID: "Milch", Spec: "2L", UUID: "a", Operation: tt.operation}
+		if change != want {
+			t.Errorf("RestoreChange(%q) = %+v, want %+v", tt.location, change, want)
+		}
+	}
+
+	// Restoring the prior state of an item undoes a change, though the
+	// item may move to the end of its list
+	for _, change := range []bringapi.ItemChange{
+		{ItemID: "Milch", Operation: bringapi.OperationComplete},
+		{ItemID: "Butter", Operation: bringapi.OperationAdd},
+		{ItemID: "Brot", Operation: bringapi.OperationRemove},
+	} {
+		list := testList()
+		prior, location := list.FindItem(change.ItemID)
+		before := *prior
+		restore := bringapi.RestoreChange(before, location)
+
+		list.Apply(change)
+		list.Apply(restore)
+		item, got := list.FindItem(change.ItemID)
+		if item == nil || got != location || item.UUID != before.UUID || item.Specification != before.Specification {
+			t.Errorf("Undoing %s of %s: got %+v in %q, want %+v in %q", change.Operation, change.ItemID, item, got, before, location)
+		}
+	}
+}
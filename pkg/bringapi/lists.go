@@ -0,0 +1,46 @@
+package bringapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUserNotFound is returned when an email address doesn't belong to a
+// Bring user.
+var ErrUserNotFound = errors.New("no Bring user with this email")
+
+// List themes.
+const (
+	ThemeHome      = "ch.publisheria.bring.theme.home"
+	ThemeOffice    = "ch.publisheria.bring.theme.office"
+	ThemeBBQ       = "ch.publisheria.bring.theme.bbq"
+	ThemeSchool    = "ch.publisheria.bring.theme.school"
+	ThemeChristmas = "ch.publisheria.bring.theme.christmas"
+)
+
+// ListTheme returns the theme ID for a short theme name such as "office".
+// Full theme IDs are returned unchanged.
+func ListTheme(name string) (string, error) {
+	themes := map[string]string{
+		"home":      ThemeHome,
+		"office":    ThemeOffice,
+		"bbq":       ThemeBBQ,
+		"school":    ThemeSchool,
+		"christmas": ThemeChristmas,
+	}
+	if theme, ok := themes[strings.ToLower(name)]; ok {
+		return theme, nil
+	}
+	for _, theme := range themes {
+		if name == theme {
+			return theme, nil
+		}
+	}
+	return "", fmt.Errorf("unknown theme %q: use home, office, bbq, school or christmas", name)
+}
+
+// ThemeName returns the short name of a theme ID, e.g. "office".
+func ThemeName(theme string) string {
+	return strings.TrimPrefix(theme, "ch.publisheria.bring.theme.")
+}
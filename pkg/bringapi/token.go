@@ -0,0 +1,28 @@
+package bringapi
+
+// TokenSource provides the credentials for requests. Token is called
+// before every request, so it should return cached credentials and
+// refresh them only when they expire.
+type TokenSource interface {
+	Token() (*Credentials, error)
+}
+
+// TokenStore persists credentials between runs. Load returns nil
+// credentials if none were saved.
+type TokenStore interface {
+	Load() (*Credentials, error)
+	Save(creds *Credentials) error
+}
+
+// StaticTokenSource returns a TokenSource that always returns creds.
+func StaticTokenSource(creds *Credentials) TokenSource {
+	return staticTokenSource{creds}
+}
+
+type staticTokenSource struct {
+	creds *Credentials
+}
+
+func (s staticTokenSource) Token() (*Credentials, error) {
+	return s.creds, nil
+}
@@ -0,0 +1,164 @@
+package bringapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julianfbeck/bring-cli/pkg/bringapi"
+)
+
+// memoryStore is a TokenStore counting saves.
+type memoryStore struct {
+	creds *bringapi.Credentials
+	saves int
+}
+
+func (s *memoryStore) Load() (*bringapi.Credentials, error) {
+	return s.creds, nil
+}
+
+func (s *memoryStore) Save(creds *bringapi.Credentials) error {
+	s.creds = creds
+	s.saves++
+	return nil
+}
+
+// newTestServer serves a token refresh and the lists of a user, checking
+// the access token and user agent of requests. refreshes counts the token
+// refreshes.
+func newTestServer(t *testing.T, userAgent string, refreshes *int32) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/bringauth/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(refreshes, 1)
+		if err := r.ParseForm(); err != nil || r.Form.Get("refresh_token") != "refresh-1" {
+			http.Error(w, "bad refresh token", http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(bringapi.TokenResponse{
+			AccessToken:  "access-2",
+			RefreshToken: "refresh-2",
+			ExpiresIn:    3600,
+		})
+	})
+	mux.HandleFunc("/bringusers/user-1/lists", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != userAgent {
+			t.Errorf("Expected User-Agent %q, got %q", userAgent, r.Header.Get("User-Agent"))
+		}
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			http.Error(w, "bad access token", http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(bringapi.ListsResponse{
+			Lists: []bringapi.ShoppingList{{ListUUID: "list-1", Name: "Home"}},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTokenStoreRefresh(t *testing.T) {
+	var refreshes int32
+	server := newTestServer(t, "test-agent", &refreshes)
+
+	// Expired credentials are loaded from the store and refreshed
+	store := &memoryStore{creds: &bringapi.Credentials{
+		UUID:         "user-1",
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	}}
+	client := bringapi.NewClient(
+		bringapi.WithBaseURL(server.URL),
+		bringapi.WithHTTPClient(server.Client()),
+		bringapi.WithUserAgent("test-agent"),
+		bringapi.WithTokenStore(store),
+	)
+
+	lists, err := client.GetLists()
+	if err != nil {
+		t.Fatalf("GetLists failed: %v", err)
+	}
+	if len(lists.Lists) != 1 || lists.Lists[0].Name != "Home" {
+		t.Errorf("Expected the Home list, got %+v", lists.Lists)
+	}
+	if store.saves != 1 || store.creds.AccessToken != "access-2" || store.creds.RefreshToken != "refresh-2" {
+		t.Errorf("Expected the refreshed credentials to be saved once, got %d saves of %+v", store.saves, store.creds)
+	}
+
+	// Valid credentials are not refreshed again
+	if _, err := client.GetLists(); err != nil {
+		t.Fatalf("GetLists failed: %v", err)
+	}
+	if store.saves != 1 {
+		t.Errorf("Expected no refresh of valid credentials, got %d saves", store.saves)
+	}
+}
+
+func TestTokenSource(t *testing.T) {
+	var refreshes int32
+	server := newTestServer(t, bringapi.DefaultUserAgent, &refreshes)
+
+	// Credentials from a token source are used as they are, even expired
+	client := bringapi.NewClient(
+		bringapi.WithBaseURL(server.URL+"/"),
+		bringapi.WithTokenSource(bringapi.StaticTokenSource(&bringapi.Credentials{
+			UUID:        "user-1",
+			AccessToken: "access-2",
+		})),
+	)
+	if _, err := client.GetLists(); err != nil {
+		t.Fatalf("GetLists failed: %v", err)
+	}
+	if refreshes != 0 {
+		t.Errorf("Expected no refresh with a token source, got %d", refreshes)
+	}
+}
+
+func TestConcurrentRefresh(t *testing.T) {
+	var refreshes int32
+	server := newTestServer(t, bringapi.DefaultUserAgent, &refreshes)
+
+	creds := &bringapi.Credentials{
+		UUID:         "user-1",
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	}
+	client := bringapi.NewClient(bringapi.WithBaseURL(server.URL), bringapi.WithCredentials(creds))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetLists(); err != nil {
+				t.Errorf("GetLists failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes != 1 {
+		t.Errorf("Expected a single refresh, got %d", refreshes)
+	}
+	if creds.AccessToken != "access-1" {
+		t.Errorf("Expected the credentials passed to WithCredentials to be left unchanged, got %q", creds.AccessToken)
+	}
+	if got := client.GetCredentials().AccessToken; got != "access-2" {
+		t.Errorf("Expected the refreshed access token, got %q", got)
+	}
+}
+
+func TestNotAuthenticated(t *testing.T) {
+	client := bringapi.NewClient(bringapi.WithTokenStore(&memoryStore{}))
+	if _, err := client.GetLists(); err == nil {
+		t.Error("Expected GetLists without credentials to fail")
+	}
+}
@@ -0,0 +1,251 @@
+package bringapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuthResponse represents the response from the Bring auth endpoint.
+type AuthResponse struct {
+	UUID          string `json:"uuid"`
+	PublicUUID    string `json:"publicUuid"`
+	Email         string `json:"email"`
+	Name          string `json:"name,omitempty"`
+	PhotoPath     string `json:"photoPath,omitempty"`
+	BringListUUID string `json:"bringListUUID"`
+	AccessToken   string `json:"access_token"`
+	RefreshToken  string `json:"refresh_token"`
+	TokenType     string `json:"token_type"`
+	ExpiresIn     int    `json:"expires_in"`
+}
+
+// TokenResponse represents the response from token refresh.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// ShoppingList represents a Bring shopping list.
+type ShoppingList struct {
+	ListUUID string `json:"listUuid"`
+	Name     string `json:"name"`
+	Theme    string `json:"theme"`
+}
+
+// CreateListResponse represents the response from creating a list.
+type CreateListResponse struct {
+	ListUUID      string `json:"listUuid"`
+	BringListUUID string `json:"bringListUUID"`
+	Name          string `json:"name"`
+	Theme         string `json:"theme"`
+}
+
+// ListsResponse represents the response from the lists endpoint.
+type ListsResponse struct {
+	Lists []ShoppingList `json:"lists"`
+}
+
+// ListItem represents an item in a shopping list.
+type ListItem struct {
+	UUID          string      `json:"uuid"`
+	ItemID        string      `json:"itemId"`
+	Specification string      `json:"specification"`
+	Attributes    []Attribute `json:"attributes,omitempty"`
+}
+
+// Attribute represents item attributes. Content depends on the type; it
+// is kept as is so unknown attributes can't break decoding a list, and is
+// decoded by ListItem.Conditions for PURCHASE_CONDITIONS.
+type Attribute struct {
+	Type    string          `json:"type"`
+	Value   string          `json:"value,omitempty"`
+	Content json.RawMessage `json:"content,omitempty"`
+}
+
+// PurchaseConditions is the content of a PURCHASE_CONDITIONS attribute.
+type PurchaseConditions struct {
+	Urgent     bool `json:"urgent"`
+	Convenient bool `json:"convenient"`
+	Discounted bool `json:"discounted"`
+}
+
+// Items contains purchase and recently completed items.
+type Items struct {
+	Purchase []ListItem `json:"purchase"`
+	Recently []ListItem `json:"recently"`
+}
+
+// ListItemsResponse represents the response from getting list items.
+type ListItemsResponse struct {
+	UUID   string `json:"uuid"`
+	Status string `json:"status"`
+	Items  Items  `json:"items"`
+}
+
+// ActivityResponse represents the response from the list activity endpoint.
+type ActivityResponse struct {
+	Timeline    []ActivityEvent `json:"timeline"`
+	Timestamp   time.Time       `json:"timestamp"`
+	TotalEvents int             `json:"totalEvents"`
+}
+
+// ActivityEvent is a single entry in a list's activity timeline.
+type ActivityEvent struct {
+	Type    string          `json:"type"`
+	Content ActivityContent `json:"content"`
+}
+
+// ActivityContent describes who changed which items in an activity event.
+type ActivityContent struct {
+	UUID           string     `json:"uuid"`
+	SessionDate    time.Time  `json:"sessionDate"`
+	PublicUserUUID string     `json:"publicUserUuid"`
+	Items          []ListItem `json:"items"`
+	Purchase       []ListItem `json:"purchase,omitempty"`
+	Recently       []ListItem `json:"recently,omitempty"`
+}
+
+// ListUser represents a member of a shopping list.
+type ListUser struct {
+	PublicUUID  string `json:"publicUuid"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	PhotoPath   string `json:"photoPath,omitempty"`
+	PushEnabled bool   `json:"pushEnabled"`
+	PlusTryOut  bool   `json:"plusTryOut"`
+	Country     string `json:"country,omitempty"`
+	Language    string `json:"language,omitempty"`
+}
+
+// ListUsersResponse represents the response from the list users endpoint.
+type ListUsersResponse struct {
+	Users []ListUser `json:"users"`
+}
+
+// BringUser identifies a Bring account found by email.
+type BringUser struct {
+	UserUUID   string `json:"userUuid"`
+	PublicUUID string `json:"publicUuid"`
+	Email      string `json:"email"`
+	Name       string `json:"name,omitempty"`
+}
+
+// ItemDetail holds per-list details of an item, such as its photo.
+type ItemDetail struct {
+	UUID           string `json:"uuid"`
+	ItemID         string `json:"itemId"`
+	ListUUID       string `json:"listUuid"`
+	UserIconItemID string `json:"userIconItemId,omitempty"`
+	UserSectionID  string `json:"userSectionId,omitempty"`
+	AssignedTo     string `json:"assignedTo,omitempty"`
+	ImageURL       string `json:"imageUrl,omitempty"`
+}
+
+// CreateItemDetailRequest represents the request body for creating item details.
+type CreateItemDetailRequest struct {
+	ListUUID string `json:"listUuid"`
+	ItemID   string `json:"itemId"`
+}
+
+// ItemImageRequest represents the request body for uploading an item photo.
+type ItemImageRequest struct {
+	ImageData string `json:"imageData"`
+}
+
+// ItemImageResponse represents the response from uploading an item photo.
+type ItemImageResponse struct {
+	ImageURL string `json:"imageUrl"`
+}
+
+// ItemChange represents a change to be made to an item.
+type ItemChange struct {
+	ItemID    string     `json:"itemId"`
+	Spec      string     `json:"spec"`
+	UUID      string     `json:"uuid,omitempty"`
+	Operation string     `json:"operation"`
+	Attribute *Attribute `json:"attribute,omitempty"`
+	Accuracy  string     `json:"accuracy,omitempty"`
+	Altitude  string     `json:"altitude,omitempty"`
+	Latitude  string     `json:"latitude,omitempty"`
+	Longitude string     `json:"longitude,omitempty"`
+}
+
+// BatchUpdateRequest represents the request body for batch updates.
+type BatchUpdateRequest struct {
+	Changes []ItemChange `json:"changes"`
+	Sender  string       `json:"sender"`
+}
+
+// NotifyRequest represents the request body for notifications.
+type NotifyRequest struct {
+	ListNotificationType       string            `json:"listNotificationType"`
+	SenderPublicUserUUID       string            `json:"senderPublicUserUuid"`
+	ReceiverPublicUserUUID     string            `json:"receiverPublicUserUuid,omitempty"`
+	Arguments                  []string          `json:"arguments,omitempty"`
+	ListActivityStreamReaction *ActivityReaction `json:"listActivityStreamReaction,omitempty"`
+}
+
+// ActivityReaction is a reaction to an event in a list's activity timeline.
+type ActivityReaction struct {
+	ModuleUUID   string `json:"moduleUuid"`
+	ModuleType   string `json:"moduleType"`
+	ReactionType string `json:"reactionType"`
+}
+
+// Credentials stores authentication credentials.
+type Credentials struct {
+	Email        string    `json:"email" yaml:"email"`
+	UUID         string    `json:"uuid" yaml:"uuid"`
+	PublicUUID   string    `json:"public_uuid" yaml:"public_uuid"`
+	AccessToken  string    `json:"access_token" yaml:"access_token"`
+	RefreshToken string    `json:"refresh_token" yaml:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at" yaml:"expires_at"`
+	DefaultList  string    `json:"default_list" yaml:"default_list"`
+}
+
+// Item operations.
+const (
+	OperationAdd      = "TO_PURCHASE"
+	OperationComplete = "TO_RECENTLY"
+	OperationRemove   = "REMOVE"
+
+	// OperationAttributeUpdate replaces an item's attribute without
+	// moving the item.
+	OperationAttributeUpdate = "ATTRIBUTE_UPDATE"
+)
+
+// AttributePurchaseConditions is the attribute type holding the urgent,
+// convenient and discounted flags of an item.
+const AttributePurchaseConditions = "PURCHASE_CONDITIONS"
+
+// Activity event types. Checking items off shows up as removed
+// items, since they are removed from the purchase list.
+const (
+	ActivityItemsAdded   = "LIST_ITEMS_ADDED"
+	ActivityItemsChanged = "LIST_ITEMS_CHANGED"
+	ActivityItemsRemoved = "LIST_ITEMS_REMOVED"
+)
+
+// Notification types.
+const (
+	NotifyGoingShopping = "GOING_SHOPPING"
+	NotifyChangedList   = "CHANGED_LIST"
+	NotifyShoppingDone  = "SHOPPING_DONE"
+
+	// NotifyUrgentMessage asks others to buy the items given as arguments.
+	NotifyUrgentMessage = "URGENT_MESSAGE"
+
+	// NotifyActivityReaction reacts to an activity event; send it with
+	// Client.React.
+	NotifyActivityReaction = "LIST_ACTIVITY_STREAM_REACTION"
+)
+
+// Reactions to activity events.
+const (
+	ReactionThumbsUp = "THUMBS_UP"
+	ReactionMonocle  = "MONOCLE"
+	ReactionDrooling = "DROOLING"
+	ReactionHeart    = "HEART"
+)